@@ -0,0 +1,87 @@
+package datetimeparser
+
+import (
+	"sort"
+	"time"
+)
+
+// Candidate is one interpretation of an input, together with the grammar
+// rules that produced it and a score used to rank it against the others.
+type Candidate struct {
+	Time  time.Time
+	Rules []string
+	Score float64
+}
+
+const (
+	assumePmScore       = 0.8
+	assumeNextWeekScore = 0.6
+	assumeNextDayScore  = 0.6
+	pastScore           = 0.4
+)
+
+func hasRule(rules []string, names ...string) bool {
+	for _, r := range rules {
+		for _, n := range names {
+			if r == n {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func expandCandidates(cs []Candidate, rule string, score float64, f func(time.Time) time.Time) []Candidate {
+	for _, c := range cs {
+		cs = append(cs, Candidate{
+			Time:  f(c.Time),
+			Rules: append(append([]string{}, c.Rules...), rule),
+			Score: c.Score * score,
+		})
+	}
+	return cs
+}
+
+// ParseCandidates parses input like ParseDateTime but returns every sensible
+// reading of it, best first. Clock times without 上午/下午 or am/pm are also
+// read as afternoon, bare weekdays as next week and times without a date as
+// tomorrow; readings that lie before Base are ranked lower. Input without a
+// time of day, such as 周一, is read as a date.
+func (dp *DateTimeParser) ParseCandidates(input string) ([]Candidate, error) {
+	result, err := dp.parseInput(input)
+	if err != nil {
+		result, err = dp.parseDateInput(input)
+		if err != nil {
+			return nil, err
+		}
+	}
+	rules := result.rules.names()
+	cs := []Candidate{{Time: dp.resultTime(result), Rules: rules, Score: 1}}
+	// Machine formats and offsets such as 2小时后 name an exact instant and
+	// have no other reading.
+	exact := result.hasInstant || hasRule(rules, "parseMachineDateTime")
+	if !exact && hasRule(rules, "parseClockTime") && !hasRule(rules, "parseAmHourMinute", "parsePmHourMinute", "parseNoonHourMinute", "parseMeridiemTime") && result.Hour >= 1 && result.Hour < 12 {
+		cs = expandCandidates(cs, "assumePm", assumePmScore, func(t time.Time) time.Time {
+			return wallTime(t.Year(), int(t.Month()), t.Day(), t.Hour()+12, t.Minute(), t.Second(), t.Location())
+		})
+	}
+	if !exact && hasRule(rules, "parseWeekday") {
+		cs = expandCandidates(cs, "assumeNextWeek", assumeNextWeekScore, func(t time.Time) time.Time {
+			return wallTime(t.Year(), int(t.Month()), t.Day()+7, t.Hour(), t.Minute(), t.Second(), t.Location())
+		})
+	}
+	if !exact && !hasRule(rules, "parseAnyDate", "parseTimePeriod") {
+		cs = expandCandidates(cs, "assumeNextDay", assumeNextDayScore, func(t time.Time) time.Time {
+			return wallTime(t.Year(), int(t.Month()), t.Day()+1, t.Hour(), t.Minute(), t.Second(), t.Location())
+		})
+	}
+	for i := range cs {
+		if cs[i].Time.Before(dp.Base) {
+			cs[i].Score *= pastScore
+		}
+	}
+	sort.SliceStable(cs, func(i, j int) bool {
+		return cs[i].Score > cs[j].Score
+	})
+	return cs, nil
+}
@@ -0,0 +1,55 @@
+package datetimeparser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCandidatesClockTime(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 20, 13, 0, 0, 0, shanghai)
+	dateParser := NewDateTimeParser(base)
+	cs, err := dateParser.ParseCandidates("3点")
+	assert(t, err, nil, "error")
+	assert(t, len(cs), 4, "candidates mismatch")
+	assert(t, cs[0].Time.Equal(time.Date(2022, time.August, 20, 15, 0, 0, 0, shanghai)), true, "first candidate mismatch")
+	assert(t, hasRule(cs[0].Rules, "parseClockTime"), true, "rule missing")
+	assert(t, hasRule(cs[0].Rules, "assumePm"), true, "rule missing")
+	assert(t, cs[1].Time.Equal(time.Date(2022, time.August, 21, 3, 0, 0, 0, shanghai)), true, "second candidate mismatch")
+	assert(t, cs[len(cs)-1].Time.Equal(time.Date(2022, time.August, 20, 3, 0, 0, 0, shanghai)), true, "last candidate mismatch")
+}
+
+func TestParseCandidatesWeekday(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 20, 12, 34, 56, 32, shanghai)
+	dateParser := NewDateTimeParser(base)
+	cs, err := dateParser.ParseCandidates("周一上午9点")
+	assert(t, err, nil, "error")
+	assert(t, len(cs), 2, "candidates mismatch")
+	assert(t, cs[0].Time.Day(), 22, "first candidate mismatch")
+	assert(t, cs[1].Time.Day(), 15, "second candidate mismatch")
+	assert(t, cs[0].Score > cs[1].Score, true, "score order mismatch")
+}
+
+func TestParseCandidatesBareWeekday(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 20, 12, 34, 56, 32, shanghai)
+	dateParser := NewDateTimeParser(base)
+	cs, err := dateParser.ParseCandidates("周一")
+	assert(t, err, nil, "error")
+	assert(t, len(cs), 2, "candidates mismatch")
+	assert(t, cs[0].Time.Equal(time.Date(2022, time.August, 22, 0, 0, 0, 0, shanghai)), true, "first candidate mismatch")
+	assert(t, hasRule(cs[0].Rules, "assumeNextWeek"), true, "rule missing")
+	assert(t, cs[1].Time.Equal(time.Date(2022, time.August, 15, 0, 0, 0, 0, shanghai)), true, "second candidate mismatch")
+}
+
+func TestParseCandidatesExactInstant(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 20, 12, 34, 56, 32, shanghai)
+	dateParser := NewDateTimeParser(base)
+	for _, input := range []string{"2016-08-12T15:04:05+08:00", "1470985445", "2016-08-12T15:04:05", "2小时后"} {
+		cs, err := dateParser.ParseCandidates(input)
+		assert(t, err, nil, input+" error")
+		assert(t, len(cs), 1, input+" candidates mismatch")
+	}
+}
@@ -0,0 +1,114 @@
+// Command datetimeparser resolves Chinese date and time expressions.
+//
+// Expressions are taken from the arguments, or one per line from stdin when
+// no arguments are given:
+//
+//	datetimeparser --base 2022-08-20T12:00:00+08:00 明天上午8点
+//	echo 下周一下午三点半 | datetimeparser --tz Asia/Shanghai --format json
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+	_ "time/tzdata"
+	"unicode/utf8"
+
+	"github.com/peixy0/datetimeparser"
+)
+
+type output struct {
+	Input string                     `json:"input"`
+	Time  string                     `json:"time,omitempty"`
+	Error string                     `json:"error,omitempty"`
+	Rules []datetimeparser.RuleMatch `json:"rules,omitempty"`
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("datetimeparser", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	base := flags.String("base", "", "base time in RFC3339, defaults to now")
+	tz := flags.String("tz", "", "IANA time zone of the base time, defaults to local")
+	strict := flags.Bool("strict", false, "reject expressions with unparsed trailing text")
+	format := flags.String("format", "rfc3339", "output format: rfc3339 or json")
+	trace := flags.Bool("trace", false, "print the grammar rules that matched")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *format != "rfc3339" && *format != "json" {
+		fmt.Fprintln(stderr, "unknown format", *format)
+		return 2
+	}
+
+	b := time.Now()
+	if *base != "" {
+		t, err := time.Parse(time.RFC3339, *base)
+		if err != nil {
+			fmt.Fprintln(stderr, "invalid base:", err)
+			return 2
+		}
+		b = t
+	}
+	if *tz != "" {
+		loc, err := time.LoadLocation(*tz)
+		if err != nil {
+			fmt.Fprintln(stderr, "invalid tz:", err)
+			return 2
+		}
+		b = b.In(loc)
+	}
+	dp := datetimeparser.NewDateTimeParser(b)
+	dp.Strict = *strict
+
+	inputs := flags.Args()
+	if len(inputs) == 0 {
+		scanner := bufio.NewScanner(stdin)
+		for scanner.Scan() {
+			if scanner.Text() != "" {
+				inputs = append(inputs, scanner.Text())
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+	}
+
+	status := 0
+	encoder := json.NewEncoder(stdout)
+	encoder.SetEscapeHTML(false)
+	for _, input := range inputs {
+		o := output{Input: input}
+		t, err := dp.ParseDateTime(input)
+		if err != nil {
+			o.Error = err.Error()
+			status = 1
+		} else {
+			o.Time = t.Format(time.RFC3339)
+			if *trace {
+				o.Rules, _ = dp.MatchRules(input)
+			}
+		}
+		if *format == "json" {
+			encoder.Encode(o)
+			continue
+		}
+		if o.Error != "" {
+			fmt.Fprintf(stderr, "%s: %s\n", input, o.Error)
+			continue
+		}
+		fmt.Fprintln(stdout, o.Time)
+		for _, r := range o.Rules {
+			fmt.Fprintf(stdout, "  %-28s %q (%d chars)\n", r.Name, r.Text, utf8.RuneCountInString(r.Text))
+		}
+	}
+	return status
+}
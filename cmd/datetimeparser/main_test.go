@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	status := run([]string{"--base", "2022-08-20T12:34:56+08:00", "明天上午8点"}, strings.NewReader(""), &stdout, &stderr)
+	if status != 0 {
+		t.Fatalf("status %v, stderr %v", status, stderr.String())
+	}
+	if stdout.String() != "2022-08-21T08:00:00+08:00\n" {
+		t.Errorf("unexpected output %q", stdout.String())
+	}
+}
+
+func TestRunStdinJSONTrace(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	stdin := strings.NewReader("下周一下午三点半\n无法解析\n")
+	status := run([]string{"--base", "2022-08-20T12:34:56+08:00", "--format", "json", "--trace"}, stdin, &stdout, &stderr)
+	if status != 1 {
+		t.Errorf("status %v, expected 1", status)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("unexpected output %q", stdout.String())
+	}
+	if !strings.Contains(lines[0], `"time":"2022-08-22T15:30:00+08:00"`) || !strings.Contains(lines[0], `"name":"parseNextWeekday"`) {
+		t.Errorf("unexpected output %q", lines[0])
+	}
+	if !strings.Contains(lines[1], `"error"`) {
+		t.Errorf("unexpected output %q", lines[1])
+	}
+}
@@ -2,14 +2,64 @@ package datetimeparser
 
 import (
 	"errors"
+	"fmt"
+	"reflect"
 	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 type DateTimeParser struct {
 	Base time.Time
+	// LongestMatch makes every choice point try all of its alternatives and
+	// keep the one consuming the most input instead of the first that succeeds.
+	LongestMatch bool
+	// Strict rejects inputs with trailing text the grammar did not consume.
+	Strict bool
+	// ConvertToBaseLocation converts times parsed in a zone named in the
+	// input back to Base's location.
+	ConvertToBaseLocation bool
+	// DateOrder is the field order of numeric dates such as 8/12/2016. Dates
+	// starting with a four digit year are always read year first.
+	DateOrder DateOrder
+	// TwoDigitYearFuture is how many years after Base a two digit year such
+	// as 16年 may expand to; other two digit years fall in the century
+	// before. NewDateTimeParser sets it to 49, a window of Base-50 to
+	// Base+49; zero places every two digit year at or before Base's year.
+	TwoDigitYearFuture int
+	// Fiscal is the fiscal calendar used by 财年, 财季 and FY periods.
+	Fiscal FiscalCalendar
+	// DayBoundary is the time of day before which Base still counts as the
+	// previous day, so that 明天 said at 1 AM means the coming morning. It
+	// applies to every day-relative expression. Zero means five hours and a
+	// negative value disables it.
+	DayBoundary time.Duration
+	// Connectives are words skipped before a date or time and between the
+	// two, as in 在明天的下午3点 or 8月12日那天. Nil means DefaultConnectives.
+	Connectives []string
+	// Tracer, when set, records every grammar rule attempted while parsing.
+	Tracer *Tracer
 }
 
+// DefaultConnectives are the prepositions and particles skipped when
+// DateTimeParser.Connectives is nil.
+var DefaultConnectives = []string{"在", "于", "的", "那天", "当天"}
+
+// defaultDayBoundary is used when DateTimeParser.DayBoundary is zero.
+const defaultDayBoundary = 5 * time.Hour
+
+// DateOrder is the order of the year, month and day fields in numeric dates.
+type DateOrder int
+
+const (
+	YMD DateOrder = iota
+	MDY
+	DMY
+)
+
 type DateTimeParseResult struct {
 	Year   int
 	Month  int
@@ -17,11 +67,64 @@ type DateTimeParseResult struct {
 	Hour   int
 	Minute int
 	Second int
+	// Location is the zone named in the input, or nil to use Base's.
+	Location *time.Location
+	// instant is set by grammars that resolve to an exact instant rather
+	// than a wall clock reading.
+	instant    time.Time
+	hasInstant bool
+	// uncertainty is how far either side of the parsed time a hedged or
+	// indefinite expression such as 3点左右 or 几分钟后 may fall.
+	uncertainty time.Duration
+	rules       *ruleNode
+}
+
+// ruleNode records a rule that matched text, leaving rest bytes of the
+// normalized input unconsumed.
+type ruleNode struct {
+	name string
+	text string
+	rest int
+	prev *ruleNode
+}
+
+// RuleMatch is a grammar rule that contributed to a parse and the text it
+// consumed. Start and End are the byte offsets of Text in the original input.
+type RuleMatch struct {
+	Name  string `json:"name"`
+	Text  string `json:"text"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
+
+func (n *ruleNode) matches(input normalized) []RuleMatch {
+	var matches []RuleMatch
+	for ; n != nil; n = n.prev {
+		end := len(input.text) - n.rest
+		start, end := input.span(end-len(n.text), end)
+		matches = append([]RuleMatch{{Name: n.name, Text: input.source[start:end], Start: start, End: end}}, matches...)
+	}
+	return matches
+}
+
+func (n *ruleNode) names() []string {
+	var names []string
+	for ; n != nil; n = n.prev {
+		names = append([]string{n.name}, names...)
+	}
+	return names
+}
+
+func sameResult(a, b DateTimeParseResult) bool {
+	a.rules, b.rules = nil, nil
+	return a == b
 }
 
 func NewDateTimeParser(base time.Time) *DateTimeParser {
 	return &DateTimeParser{
-		Base: base,
+		Base:               base,
+		TwoDigitYearFuture: 49,
+		DayBoundary:        defaultDayBoundary,
 	}
 }
 
@@ -45,6 +148,70 @@ func parseAnyOf[T any](fs []ParseFunc[T]) ParseFunc[T] {
 	}
 }
 
+// AmbiguousParseError is returned in longest-match mode when alternatives
+// consuming the same, longest, span of input disagree on the result.
+type AmbiguousParseError[T any] struct {
+	Rest       string
+	Candidates []T
+}
+
+func (e *AmbiguousParseError[T]) Error() string {
+	return fmt.Sprintf("ambiguous parse: %d candidates", len(e.Candidates))
+}
+
+func parseLongestOf[T any](fs []ParseFunc[T], same func(T, T) bool) ParseFunc[T] {
+	return func(input string, result *T) (string, error) {
+		best := -1
+		rest := input
+		var candidates []T
+		for _, f := range fs {
+			r := *result
+			rs, err := f(input, &r)
+			var found []T
+			var amb *AmbiguousParseError[T]
+			if err == nil {
+				found = []T{r}
+			} else if errors.As(err, &amb) {
+				rs = amb.Rest
+				found = amb.Candidates
+			} else {
+				continue
+			}
+			consumed := len(input) - len(rs)
+			if consumed < best {
+				continue
+			}
+			if consumed > best {
+				best = consumed
+				rest = rs
+				candidates = nil
+			}
+			for _, c := range found {
+				if !contains(candidates, c, same) {
+					candidates = append(candidates, c)
+				}
+			}
+		}
+		if best < 0 {
+			return input, errors.New("not parsed any of")
+		}
+		if len(candidates) > 1 {
+			return input, &AmbiguousParseError[T]{Rest: rest, Candidates: candidates}
+		}
+		*result = candidates[0]
+		return rest, nil
+	}
+}
+
+func contains[T any](s []T, v T, same func(T, T) bool) bool {
+	for _, e := range s {
+		if same(e, v) {
+			return true
+		}
+	}
+	return false
+}
+
 func parseAllOf[T any](fs []ParseFunc[T]) ParseFunc[T] {
 	return func(input string, result *T) (string, error) {
 		rest := input
@@ -101,17 +268,66 @@ func parseNumberWithUnit(input string, unit string, r *int) (string, error) {
 	return rest, nil
 }
 
-var chineseDigits = []string{"(〇|零)", "一", "(二|两)", "三", "四", "五", "六", "七", "八", "九", "十", "十一", "十二"}
+var chineseNumerals = map[rune]int{
+	'〇': 0, '零': 0, '一': 1, '二': 2, '两': 2, '三': 3, '四': 4,
+	'五': 5, '六': 6, '七': 7, '八': 8, '九': 9,
+}
+
+var chineseMultipliers = map[rune]int{'十': 10, '百': 100, '千': 1000}
 
+// parseChineseNumber parses numbers written with multipliers, such as 十一,
+// 二十三 or 一百零五.
 func parseChineseNumber(input string, r *int) (string, error) {
-	for i, d := range chineseDigits {
-		rest, err := parseRegex(input, d)
-		if err == nil {
-			*r = i
-			return rest, nil
+	n, digit, parsed := 0, -1, 0
+	for _, c := range input {
+		if d, ok := chineseNumerals[c]; ok {
+			if digit >= 0 {
+				break
+			}
+			if d != 0 || parsed == 0 {
+				digit = d
+			}
+		} else if m, ok := chineseMultipliers[c]; ok {
+			if digit < 0 && parsed > 0 {
+				break
+			}
+			if digit < 0 {
+				digit = 1
+			}
+			n += digit * m
+			digit = -1
+		} else {
+			break
+		}
+		parsed += utf8.RuneLen(c)
+	}
+	if parsed == 0 {
+		return input, errors.New("chinese number not parsed")
+	}
+	if digit >= 0 {
+		n += digit
+	}
+	*r = n
+	return input[parsed:], nil
+}
+
+// parseChineseDigits parses digits spelled one by one, as in 二〇一六 or 九九.
+func parseChineseDigits(input string, r *int) (string, error) {
+	n, parsed, count := 0, 0, 0
+	for _, c := range input {
+		d, ok := chineseNumerals[c]
+		if !ok || c == '两' {
+			break
 		}
+		n = n*10 + d
+		parsed += utf8.RuneLen(c)
+		count++
+	}
+	if count < 2 {
+		return input, errors.New("chinese digits not parsed")
 	}
-	return input, errors.New("chinese number not parsed")
+	*r = n
+	return input[parsed:], nil
 }
 
 func parseAnyNumber(input string, r *int) (string, error) {
@@ -151,100 +367,225 @@ func parseWeekday(input string, r *int) (string, error) {
 		*r = 0
 		return rest, nil
 	}
-	var w int
-	rest, err = parseChineseNumber(rest, &w)
-	if err != nil {
-		return rest, errors.New("weekday not parsed")
-	}
-	if w < 1 || w > 6 {
+	// Read a single numeral so that the hour in 周二十点 is left alone.
+	c, size := utf8.DecodeRuneInString(rest)
+	w, ok := chineseNumerals[c]
+	if !ok || w < 1 || w > 6 {
 		return input, errors.New("weekday not parsed")
 	}
+	rest = rest[size:]
 	*r = w
 	return rest, nil
 }
 
-func (dp *DateTimeParser) ignore(input string, _ *DateTimeParseResult) (string, error) {
-	return input, nil
+func ruleName[T any](f ParseFunc[T]) string {
+	name := runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
+	if !strings.HasSuffix(name, "-fm") {
+		return ""
+	}
+	name = strings.TrimSuffix(name, "-fm")
+	return name[strings.LastIndex(name, ".")+1:]
 }
 
-func (dp *DateTimeParser) parseWithHalfHourPeriod(input string, result *DateTimeParseResult) (string, error) {
-	var h int = 0
-	rest, err := parseAnyOf(ParseFuncList[DateTimeParseResult]{
-		func(input string, _ *DateTimeParseResult) (string, error) {
-			return parseNumberWithUnit(input, "个半(小时|钟头)(以)?后", &h)
-		},
-		func(input string, _ *DateTimeParseResult) (string, error) {
-			return parseRegex(input, "半(个)?(小时|钟头)(以)?后")
-		},
-	})(input, result)
-	if err != nil {
-		return input, err
+func (dp *DateTimeParser) withRules(fs ParseFuncList[DateTimeParseResult]) ParseFuncList[DateTimeParseResult] {
+	named := make(ParseFuncList[DateTimeParseResult], len(fs))
+	for i, f := range fs {
+		named[i] = dp.withRule(f)
 	}
-	t := dp.Base.Add(time.Duration(h)*time.Hour + 30*time.Minute)
-	result.Hour = t.Hour()
-	result.Minute = t.Minute()
-	result.Second = dp.Base.Second()
-	return rest, nil
+	return named
 }
 
-func (dp *DateTimeParser) parseHourPeriod(input string, result *DateTimeParseResult) (string, error) {
-	var h int
-	rest, err := parseNumberWithUnit(input, "(个)?(小时|钟头)(以)?后", &h)
-	if err != nil {
-		return input, err
+func (dp *DateTimeParser) withRule(f ParseFunc[DateTimeParseResult]) ParseFunc[DateTimeParseResult] {
+	name := ruleName(f)
+	if name == "" {
+		return f
+	}
+	return func(input string, result *DateTimeParseResult) (string, error) {
+		if dp.Tracer != nil {
+			dp.Tracer.enter(name, input)
+		}
+		rest, err := f(input, result)
+		if dp.Tracer != nil {
+			dp.Tracer.exit(input[:len(input)-len(rest)], *result, err)
+		}
+		if err == nil {
+			result.rules = &ruleNode{name: name, text: input[:len(input)-len(rest)], rest: len(rest), prev: result.rules}
+		}
+		return rest, err
 	}
-	t := dp.Base.Add(time.Duration(h) * time.Hour)
-	result.Hour = t.Hour()
-	result.Minute = t.Minute()
-	result.Second = dp.Base.Second()
-	return rest, nil
 }
 
-func (dp *DateTimeParser) parseMinutePeriod(input string, result *DateTimeParseResult) (string, error) {
-	var m int
-	rest, err := parseNumberWithUnit(input, "(分钟|分)(以)?后", &m)
-	if err != nil {
-		return input, err
+func (dp *DateTimeParser) anyOf(fs ParseFuncList[DateTimeParseResult]) ParseFunc[DateTimeParseResult] {
+	if dp.LongestMatch {
+		return parseLongestOf(dp.withRules(fs), sameResult)
+	}
+	return parseAnyOf(dp.withRules(fs))
+}
+
+func (dp *DateTimeParser) allOf(fs ParseFuncList[DateTimeParseResult]) ParseFunc[DateTimeParseResult] {
+	return parseAllOf(dp.withRules(fs))
+}
+
+// relativePeriod is a word naming a day, month or year by its offset from
+// Base. name is the canonical spelling used when rendering and ex the
+// pattern accepted when parsing.
+type relativePeriod struct {
+	name   string
+	ex     string
+	offset int
+}
+
+var relativeDays = []relativePeriod{
+	{"前天", "前(天|日)", -2},
+	{"昨天", "昨(天|日)", -1},
+	{"今天", "今(天|日)", 0},
+	{"明天", "明(天|日)", 1},
+	{"后天", "后(天|日)", 2},
+}
+
+var relativeMonths = []relativePeriod{
+	{"上个月", "上个月", -1},
+	{"这个月", "(这(个)?|本)月", 0},
+	{"下个月", "下个月", 1},
+}
+
+var relativeYears = []relativePeriod{
+	{"前年", "前年", -2},
+	{"去年", "去年", -1},
+	{"今年", "(今|本)年", 0},
+	{"明年", "明年", 1},
+	{"后年", "后年", 2},
+}
+
+// relativeWord returns the entry of table with the given offset.
+func relativeWord(table []relativePeriod, offset int) (relativePeriod, bool) {
+	for _, w := range table {
+		if w.offset == offset {
+			return w, true
+		}
+	}
+	return relativePeriod{}, false
+}
+
+func parseRelativeWord(input string, table []relativePeriod, offset int) (string, error) {
+	w, _ := relativeWord(table, offset)
+	return parseRegex(input, w.ex)
+}
+
+// parseDirection parses the suffix of a period, setting r to 1 for 后, 以后
+// and 之后 or to -1 for 前, 以前 and 之前.
+func parseDirection(input string, r *int) (string, error) {
+	rest, err := parseRegex(input, "(以|之)?后")
+	if err == nil {
+		*r = 1
+		return rest, nil
+	}
+	rest, err = parseRegex(input, "(以|之)?前")
+	if err == nil {
+		*r = -1
+		return rest, nil
 	}
-	t := dp.Base.Add(time.Duration(m) * time.Minute)
+	return input, errors.New("direction not parsed")
+}
+
+func (dp *DateTimeParser) ignore(input string, _ *DateTimeParseResult) (string, error) {
+	return input, nil
+}
+
+// setInstant resolves result to the exact instant t, keeping its wall clock
+// fields in t's location.
+func (dp *DateTimeParser) setInstant(result *DateTimeParseResult, t time.Time) {
+	result.Year = t.Year()
+	result.Month = int(t.Month())
+	result.Day = t.Day()
 	result.Hour = t.Hour()
 	result.Minute = t.Minute()
-	result.Second = dp.Base.Second()
-	return rest, nil
+	result.Second = t.Second()
+	result.instant = t
+	result.hasInstant = true
 }
 
-func (dp *DateTimeParser) parseHourMinutePeriod(input string, result *DateTimeParseResult) (string, error) {
-	var h, m int
-	rest, err := parseNumberWithUnit(input, "(个)?(小时|时|钟头)", &h)
+// baseDate returns the calendar date years, months and days away from the
+// day Base belongs to, which before DayBoundary is the previous calendar day.
+// Calendar arithmetic is done at noon so that the date is never shifted by a
+// daylight saving transition at midnight.
+func (dp *DateTimeParser) baseDate(years int, months int, days int) time.Time {
+	b := dp.Base
+	sinceMidnight := b.Sub(time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, b.Location()))
+	today := time.Date(b.Year(), b.Month(), b.Day(), 12, 0, 0, 0, b.Location())
+	boundary := dp.DayBoundary
+	if boundary == 0 {
+		boundary = defaultDayBoundary
+	}
+	if sinceMidnight < boundary {
+		today = time.Date(b.Year(), b.Month(), b.Day()-1, 12, 0, 0, 0, b.Location())
+	}
+	return time.Date(today.Year()+years, today.Month()+time.Month(months), today.Day()+days, 12, 0, 0, 0, b.Location())
+}
+
+// parseDurationPeriod parses an offset of mixed units such as 1天3小时后 or
+// 三小时二十分钟三十秒前. Calendar units move the wall clock date and the rest is
+// elapsed time, as in Duration.AddTo.
+func (dp *DateTimeParser) parseDurationPeriod(input string, result *DateTimeParseResult) (string, error) {
+	var d Duration
+	var sign int
+	rest, err := parseDuration(input, &d)
 	if err != nil {
 		return input, err
 	}
-	rest, err = parseNumberWithUnit(rest, "(分钟|分)(以)?后", &m)
+	rest, err = parseDirection(rest, &sign)
 	if err != nil {
 		return input, err
 	}
-	t := dp.Base.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute)
-	result.Hour = t.Hour()
-	result.Minute = t.Minute()
-	result.Second = dp.Base.Second()
+	if sign < 0 {
+		d = d.negate()
+	}
+	dp.setInstant(result, d.AddTo(dp.Base))
 	return rest, nil
 }
 
 func (dp *DateTimeParser) parseTimePeriod(input string, result *DateTimeParseResult) (string, error) {
-	return parseAnyOf(ParseFuncList[DateTimeParseResult]{
-		dp.parseWithHalfHourPeriod,
-		dp.parseHourMinutePeriod,
-		dp.parseHourPeriod,
-		dp.parseMinutePeriod,
+	return dp.anyOf(ParseFuncList[DateTimeParseResult]{
+		dp.parseFuzzyPeriod,
+		dp.parseDurationPeriod,
 	})(input, result)
 }
 
+// expandYear expands a two digit year to the year within the window
+// configured by TwoDigitYearFuture around Base.
+func (dp *DateTimeParser) expandYear(y int) int {
+	latest := dp.Base.Year() + dp.TwoDigitYearFuture
+	y += latest - latest%100
+	if y > latest {
+		y -= 100
+	}
+	return y
+}
+
 func (dp *DateTimeParser) parseYear(input string, result *DateTimeParseResult) (string, error) {
+	rest, abbreviated := input, false
+	if r, err := parseRegex(input, "['’]"); err == nil {
+		rest, abbreviated = r, true
+	}
 	var y int
-	rest, err := parseNumberWithUnit(input, "年", &y)
+	digits := rest
+	rest, err := parseAnyOf(ParseFuncList[int]{
+		parseNumericNumber,
+		parseChineseDigits,
+	})(rest, &y)
 	if err != nil {
 		return input, err
 	}
+	n := utf8.RuneCountInString(digits[:len(digits)-len(rest)])
+	rest, err = parseRegex(rest, "年")
+	if err != nil {
+		return input, errors.New("expecting unit 年")
+	}
+	if n == 2 {
+		y = dp.expandYear(y)
+	} else if abbreviated {
+		return input, errors.New("abbreviated year not parsed")
+	}
 	result.Year = y
 	return rest, nil
 }
@@ -269,20 +610,84 @@ func (dp *DateTimeParser) parseDay(input string, result *DateTimeParseResult) (s
 	return rest, nil
 }
 
+// parseMonthEnd parses 月底 or 月末, or the 底 of an already parsed month such
+// as 下个月底 or 12月底, moving result to the last day of its month.
+func (dp *DateTimeParser) parseMonthEnd(input string, result *DateTimeParseResult) (string, error) {
+	rest, err := parseRegex(input, "月?(底|末)")
+	if err != nil {
+		return input, err
+	}
+	result.Day = time.Date(result.Year, time.Month(result.Month)+1, 0, 12, 0, 0, 0, time.UTC).Day()
+	return rest, nil
+}
+
 func (dp *DateTimeParser) parseYMD(input string, result *DateTimeParseResult) (string, error) {
-	return parseAllOf(ParseFuncList[DateTimeParseResult]{dp.parseYear, dp.parseMonth, dp.parseDay})(input, result)
+	return dp.allOf(ParseFuncList[DateTimeParseResult]{dp.parseYear, dp.parseMonth, dp.parseDay})(input, result)
 }
 
 func (dp *DateTimeParser) parseMD(input string, result *DateTimeParseResult) (string, error) {
-	return parseAllOf(ParseFuncList[DateTimeParseResult]{dp.parseMonth, dp.parseDay})(input, result)
+	return dp.allOf(ParseFuncList[DateTimeParseResult]{dp.parseMonth, dp.parseDay})(input, result)
+}
+
+var numericDateRegex = regexp.MustCompile(`^(\d{1,4})([-/.])(\d{1,2})(([-/.])(\d{1,4}))?\s*`)
+
+var compactDateRegex = regexp.MustCompile(`^(\d{4})(\d{2})(\d{2})(\D|$)`)
+
+func (dp *DateTimeParser) parseNumericDate(input string, result *DateTimeParseResult) (string, error) {
+	var y, m, d int
+	var rest string
+	if c := compactDateRegex.FindStringSubmatch(input); c != nil {
+		y, _ = strconv.Atoi(c[1])
+		m, _ = strconv.Atoi(c[2])
+		d, _ = strconv.Atoi(c[3])
+		rest = strings.TrimLeft(input[len(c[0])-len(c[4]):], " ")
+	} else if n := numericDateRegex.FindStringSubmatch(input); n != nil {
+		a, _ := strconv.Atoi(n[1])
+		b, _ := strconv.Atoi(n[3])
+		c, _ := strconv.Atoi(n[6])
+		if len(n[1]) == 2 && n[4] != "" && dp.DateOrder == YMD {
+			a = dp.expandYear(a)
+		}
+		if len(n[6]) == 2 && len(n[1]) <= 2 && dp.DateOrder != YMD {
+			c = dp.expandYear(c)
+		}
+		switch {
+		case n[4] == "" && len(n[1]) <= 2:
+			m, d = a, b
+			if dp.DateOrder == DMY {
+				m, d = b, a
+			}
+			y = result.Year
+		case n[4] == "":
+			return input, errors.New("numeric date not parsed")
+		case n[2] != n[5]:
+			return input, errors.New("inconsistent date separators")
+		case len(n[1]) == 4 || dp.DateOrder == YMD:
+			y, m, d = a, b, c
+		case dp.DateOrder == MDY:
+			m, d, y = a, b, c
+		default:
+			d, m, y = a, b, c
+		}
+		rest = input[len(n[0]):]
+	} else {
+		return input, errors.New("numeric date not parsed")
+	}
+	if m < 1 || m > 12 || d < 1 || d > 31 {
+		return input, errors.New("numeric date out of range")
+	}
+	result.Year = y
+	result.Month = m
+	result.Day = d
+	return rest, nil
 }
 
 func (dp *DateTimeParser) parseLastYear(input string, result *DateTimeParseResult) (string, error) {
-	rest, err := parseRegex(input, "去年")
+	rest, err := parseRelativeWord(input, relativeYears, -1)
 	if err != nil {
 		return rest, err
 	}
-	n := dp.Base.AddDate(-1, 0, 0)
+	n := dp.baseDate(-1, 0, 0)
 	result.Year = n.Year()
 	result.Month = int(n.Month())
 	result.Day = n.Day()
@@ -290,11 +695,11 @@ func (dp *DateTimeParser) parseLastYear(input string, result *DateTimeParseResul
 }
 
 func (dp *DateTimeParser) parseNextYear(input string, result *DateTimeParseResult) (string, error) {
-	rest, err := parseRegex(input, "明年")
+	rest, err := parseRelativeWord(input, relativeYears, 1)
 	if err != nil {
 		return rest, err
 	}
-	n := dp.Base.AddDate(1, 0, 0)
+	n := dp.baseDate(1, 0, 0)
 	result.Year = n.Year()
 	result.Month = int(n.Month())
 	result.Day = n.Day()
@@ -302,11 +707,11 @@ func (dp *DateTimeParser) parseNextYear(input string, result *DateTimeParseResul
 }
 
 func (dp *DateTimeParser) parseThisMonth(input string, result *DateTimeParseResult) (string, error) {
-	rest, err := parseRegex(input, "(这(个)?|本)月")
+	rest, err := parseRelativeWord(input, relativeMonths, 0)
 	if err != nil {
 		return rest, err
 	}
-	n := dp.Base
+	n := dp.baseDate(0, 0, 0)
 	result.Year = n.Year()
 	result.Month = int(n.Month())
 	result.Day = n.Day()
@@ -314,11 +719,11 @@ func (dp *DateTimeParser) parseThisMonth(input string, result *DateTimeParseResu
 }
 
 func (dp *DateTimeParser) parseLastMonth(input string, result *DateTimeParseResult) (string, error) {
-	rest, err := parseRegex(input, "上个月")
+	rest, err := parseRelativeWord(input, relativeMonths, -1)
 	if err != nil {
 		return rest, err
 	}
-	n := dp.Base.AddDate(0, -1, 0)
+	n := dp.baseDate(0, -1, 0)
 	result.Year = n.Year()
 	result.Month = int(n.Month())
 	result.Day = n.Day()
@@ -326,11 +731,11 @@ func (dp *DateTimeParser) parseLastMonth(input string, result *DateTimeParseResu
 }
 
 func (dp *DateTimeParser) parseNextMonth(input string, result *DateTimeParseResult) (string, error) {
-	rest, err := parseRegex(input, "下个月")
+	rest, err := parseRelativeWord(input, relativeMonths, 1)
 	if err != nil {
 		return rest, err
 	}
-	n := dp.Base.AddDate(0, 1, 0)
+	n := dp.baseDate(0, 1, 0)
 	result.Year = n.Year()
 	result.Month = int(n.Month())
 	result.Day = n.Day()
@@ -338,11 +743,11 @@ func (dp *DateTimeParser) parseNextMonth(input string, result *DateTimeParseResu
 }
 
 func (dp *DateTimeParser) parseYesterday(input string, result *DateTimeParseResult) (string, error) {
-	rest, err := parseRegex(input, "昨(天|日)")
+	rest, err := parseRelativeWord(input, relativeDays, -1)
 	if err != nil {
 		return rest, err
 	}
-	n := dp.Base.AddDate(0, 0, -1)
+	n := dp.baseDate(0, 0, -1)
 	result.Year = n.Year()
 	result.Month = int(n.Month())
 	result.Day = n.Day()
@@ -350,11 +755,11 @@ func (dp *DateTimeParser) parseYesterday(input string, result *DateTimeParseResu
 }
 
 func (dp *DateTimeParser) parseDayBeforeYesterday(input string, result *DateTimeParseResult) (string, error) {
-	rest, err := parseRegex(input, "前(天|日)")
+	rest, err := parseRelativeWord(input, relativeDays, -2)
 	if err != nil {
 		return rest, err
 	}
-	n := dp.Base.AddDate(0, 0, -2)
+	n := dp.baseDate(0, 0, -2)
 	result.Year = n.Year()
 	result.Month = int(n.Month())
 	result.Day = n.Day()
@@ -362,26 +767,23 @@ func (dp *DateTimeParser) parseDayBeforeYesterday(input string, result *DateTime
 }
 
 func (dp *DateTimeParser) parseToday(input string, result *DateTimeParseResult) (string, error) {
-	rest, err := parseRegex(input, "今(天|日)")
+	rest, err := parseRelativeWord(input, relativeDays, 0)
 	if err != nil {
 		return rest, err
 	}
-	result.Year = dp.Base.Year()
-	result.Month = int(dp.Base.Month())
-	result.Day = dp.Base.Day()
+	n := dp.baseDate(0, 0, 0)
+	result.Year = n.Year()
+	result.Month = int(n.Month())
+	result.Day = n.Day()
 	return rest, nil
 }
 
 func (dp *DateTimeParser) parseNextDay(input string, result *DateTimeParseResult) (string, error) {
-	rest, err := parseRegex(input, "明(天|日)")
+	rest, err := parseRelativeWord(input, relativeDays, 1)
 	if err != nil {
 		return rest, err
 	}
-	d := 1
-	if dp.Base.Hour() < 5 {
-		d = 0
-	}
-	n := dp.Base.AddDate(0, 0, d)
+	n := dp.baseDate(0, 0, 1)
 	result.Year = n.Year()
 	result.Month = int(n.Month())
 	result.Day = n.Day()
@@ -389,15 +791,34 @@ func (dp *DateTimeParser) parseNextDay(input string, result *DateTimeParseResult
 }
 
 func (dp *DateTimeParser) parseDayAfterNextDay(input string, result *DateTimeParseResult) (string, error) {
-	rest, err := parseRegex(input, "后(天|日)")
+	rest, err := parseRelativeWord(input, relativeDays, 2)
 	if err != nil {
 		return rest, err
 	}
-	d := 2
-	if dp.Base.Hour() < 5 {
-		d = 1
+	n := dp.baseDate(0, 0, 2)
+	result.Year = n.Year()
+	result.Month = int(n.Month())
+	result.Day = n.Day()
+	return rest, nil
+}
+
+// parseDayOffset parses a whole number of days, weeks, months or years from
+// today, such as 两天后 or 三周前, as a date so that a time can follow it.
+func (dp *DateTimeParser) parseDayOffset(input string, result *DateTimeParseResult) (string, error) {
+	var d Duration
+	var sign int
+	rest, err := parseDuration(input, &d)
+	if err != nil {
+		return input, err
+	}
+	rest, err = parseDirection(rest, &sign)
+	if err != nil {
+		return input, err
 	}
-	n := dp.Base.AddDate(0, 0, d)
+	if d.Clock != 0 {
+		return input, errors.New("day offset not in whole days")
+	}
+	n := dp.baseDate(sign*d.Years, sign*d.Months, sign*d.Days)
 	result.Year = n.Year()
 	result.Month = int(n.Month())
 	result.Day = n.Day()
@@ -410,11 +831,11 @@ func (dp *DateTimeParser) parseWeekday(input string, result *DateTimeParseResult
 	if err != nil {
 		return rest, err
 	}
-	if w == 0 && int(dp.Base.Weekday()) != 0 {
+	if w == 0 && int(dp.baseDate(0, 0, 0).Weekday()) != 0 {
 		w = 7
 	}
-	d := w - int(dp.Base.Weekday())
-	n := dp.Base.AddDate(0, 0, d)
+	d := w - int(dp.baseDate(0, 0, 0).Weekday())
+	n := dp.baseDate(0, 0, d)
 	result.Year = n.Year()
 	result.Month = int(n.Month())
 	result.Day = n.Day()
@@ -431,11 +852,11 @@ func (dp *DateTimeParser) parseLastWeekday(input string, result *DateTimeParseRe
 	if err != nil {
 		return rest, err
 	}
-	if w == 0 && int(dp.Base.Weekday()) != 0 {
+	if w == 0 && int(dp.baseDate(0, 0, 0).Weekday()) != 0 {
 		w = 7
 	}
-	d := -7 + (w - int(dp.Base.Weekday()))
-	n := dp.Base.AddDate(0, 0, d)
+	d := -7 + (w - int(dp.baseDate(0, 0, 0).Weekday()))
+	n := dp.baseDate(0, 0, d)
 	result.Year = n.Year()
 	result.Month = int(n.Month())
 	result.Day = n.Day()
@@ -453,11 +874,11 @@ func (dp *DateTimeParser) parseNextWeekday(input string, result *DateTimeParseRe
 		return rest, err
 	}
 	w += 7
-	d := w - int(dp.Base.Weekday())
+	d := w - int(dp.baseDate(0, 0, 0).Weekday())
 	if w == 7 && d < 7 {
 		d += 7
 	}
-	n := dp.Base.AddDate(0, 0, d)
+	n := dp.baseDate(0, 0, d)
 	result.Year = n.Year()
 	result.Month = int(n.Month())
 	result.Day = n.Day()
@@ -475,11 +896,11 @@ func (dp *DateTimeParser) parseWeekAfterNextWeekday(input string, result *DateTi
 		return rest, err
 	}
 	w += 7
-	d := w - int(dp.Base.Weekday())
+	d := w - int(dp.baseDate(0, 0, 0).Weekday())
 	if w == 7 && d < 7 {
 		d += 7
 	}
-	n := dp.Base.AddDate(0, 0, d)
+	n := dp.baseDate(0, 0, d)
 	result.Year = n.Year()
 	result.Month = int(n.Month())
 	result.Day = n.Day()
@@ -487,7 +908,7 @@ func (dp *DateTimeParser) parseWeekAfterNextWeekday(input string, result *DateTi
 }
 
 func (dp *DateTimeParser) parseNormHourMinute(input string, result *DateTimeParseResult) (string, error) {
-	var h, m int
+	var h, m, sec int
 	rest, err := parseNumericNumber(input, &h)
 	if err != nil {
 		return rest, err
@@ -500,13 +921,19 @@ func (dp *DateTimeParser) parseNormHourMinute(input string, result *DateTimePars
 	if err != nil {
 		return rest, err
 	}
+	if next, err := parseRegex(rest, ":"); err == nil {
+		if next, err = parseNumericNumber(next, &sec); err == nil {
+			rest = next
+		}
+	}
 	result.Hour = h
 	result.Minute = m
+	result.Second = sec
 	return rest, nil
 }
 
 func (dp *DateTimeParser) parseAmHourMinute(input string, result *DateTimeParseResult) (string, error) {
-	return parseAllOf(ParseFuncList[DateTimeParseResult]{
+	return dp.allOf(ParseFuncList[DateTimeParseResult]{
 		func(input string, _ *DateTimeParseResult) (string, error) {
 			return parseRegex(input, "(上午|凌晨|早上)")
 		},
@@ -515,7 +942,7 @@ func (dp *DateTimeParser) parseAmHourMinute(input string, result *DateTimeParseR
 }
 
 func (dp *DateTimeParser) parsePmHourMinute(input string, result *DateTimeParseResult) (string, error) {
-	rest, err := parseAllOf(ParseFuncList[DateTimeParseResult]{
+	rest, err := dp.allOf(ParseFuncList[DateTimeParseResult]{
 		func(input string, _ *DateTimeParseResult) (string, error) {
 			return parseRegex(input, "(下午|晚上)")
 		},
@@ -527,9 +954,22 @@ func (dp *DateTimeParser) parsePmHourMinute(input string, result *DateTimeParseR
 	return rest, err
 }
 
+func (dp *DateTimeParser) parseNoonHourMinute(input string, result *DateTimeParseResult) (string, error) {
+	rest, err := dp.allOf(ParseFuncList[DateTimeParseResult]{
+		func(input string, _ *DateTimeParseResult) (string, error) {
+			return parseRegex(input, "中午")
+		},
+		dp.parseClockTime,
+	})(input, result)
+	if err == nil && result.Hour < 6 {
+		result.Hour += 12
+	}
+	return rest, err
+}
+
 func (dp *DateTimeParser) parseNumberHour(input string, result *DateTimeParseResult) (string, error) {
 	var h int
-	rest, err := parseNumberWithUnit(input, "(点|时)", &h)
+	rest, err := parseNumberWithUnit(input, "(点钟|点|时)", &h)
 	if err != nil {
 		return input, err
 	}
@@ -563,59 +1003,250 @@ func (dp *DateTimeParser) parseHourMinute(input string, result *DateTimeParseRes
 	return rest, nil
 }
 
-func (dp *DateTimeParser) parseAnyDate(input string, result *DateTimeParseResult) (string, error) {
-	return parseAnyOf(ParseFuncList[DateTimeParseResult]{
+// parseMeridiem parses a Latin am or pm suffix in any case, with or without
+// dots and spaces, as in 3pm, 10:30AM or 3:30 p.m.
+func parseMeridiem(input string, pm *bool) (string, error) {
+	if rest, err := parseRegex(input, `\s*(?i)a\.?\s*m\.?`); err == nil {
+		*pm = false
+		return rest, nil
+	}
+	if rest, err := parseRegex(input, `\s*(?i)p\.?\s*m\.?`); err == nil {
+		*pm = true
+		return rest, nil
+	}
+	return input, errors.New("meridiem not parsed")
+}
+
+// parseMeridiemTime parses a numeric clock time followed by am or pm, such
+// as 3pm, 10:30AM or 3点半pm.
+func (dp *DateTimeParser) parseMeridiemTime(input string, result *DateTimeParseResult) (string, error) {
+	rest, err := dp.anyOf(ParseFuncList[DateTimeParseResult]{
+		dp.parseNormHourMinute,
+		dp.parseHourMinute,
+		dp.parseNumberHour,
+		func(input string, result *DateTimeParseResult) (string, error) {
+			result.Minute = 0
+			return parseNumericNumber(input, &result.Hour)
+		},
+	})(input, result)
+	if err != nil {
+		return input, err
+	}
+	var pm bool
+	rest, err = parseMeridiem(rest, &pm)
+	if err != nil {
+		return input, err
+	}
+	if result.Hour < 1 || result.Hour > 12 {
+		return input, errors.New("hour out of range for am or pm")
+	}
+	if pm && result.Hour < 12 {
+		result.Hour += 12
+	} else if !pm && result.Hour == 12 {
+		result.Hour = 0
+	}
+	return rest, nil
+}
+
+// parseMinutesShort parses the amount an hour is short by in 差一刻 or
+// 差五分.
+func parseMinutesShort(input string, r *int) (string, error) {
+	rest, err := parseRegex(input, "差")
+	if err != nil {
+		return input, err
+	}
+	var n int
+	if next, err := parseNumberWithUnit(rest, "刻(钟)?", &n); err == nil {
+		*r = n * 15
+		return next, nil
+	}
+	if next, err := parseNumberWithUnit(rest, "分(钟)?", &n); err == nil {
+		*r = n
+		return next, nil
+	}
+	return input, errors.New("minutes short not parsed")
+}
+
+// setHourLessMinutes sets result to m minutes before hour h, moving back to
+// the previous day when that crosses midnight, as in 明天差五分零点.
+func (dp *DateTimeParser) setHourLessMinutes(result *DateTimeParseResult, h int, m int) {
+	t := h*60 - m
+	if t < 0 {
+		t += 24 * 60
+		d := time.Date(result.Year, time.Month(result.Month), result.Day-1, 12, 0, 0, 0, time.UTC)
+		result.Year, result.Month, result.Day = d.Year(), int(d.Month()), d.Day()
+	}
+	result.Hour = t / 60
+	result.Minute = t % 60
+}
+
+// parseHourLessMinute parses 三点差一刻 or 两点差10分.
+func (dp *DateTimeParser) parseHourLessMinute(input string, result *DateTimeParseResult) (string, error) {
+	var h, m int
+	rest, err := parseNumberWithUnit(input, "(点|时)", &h)
+	if err != nil {
+		return input, err
+	}
+	rest, err = parseMinutesShort(rest, &m)
+	if err != nil {
+		return input, err
+	}
+	dp.setHourLessMinutes(result, h, m)
+	return rest, nil
+}
+
+// parseLessMinuteHour parses 差五分三点 or 差一刻两点.
+func (dp *DateTimeParser) parseLessMinuteHour(input string, result *DateTimeParseResult) (string, error) {
+	var h, m int
+	rest, err := parseMinutesShort(input, &m)
+	if err != nil {
+		return input, err
+	}
+	rest, err = parseNumberWithUnit(rest, "(点|时)", &h)
+	if err != nil {
+		return input, err
+	}
+	dp.setHourLessMinutes(result, h, m)
+	return rest, nil
+}
+
+func (dp *DateTimeParser) parseSimpleDate(input string, result *DateTimeParseResult) (string, error) {
+	return dp.anyOf(ParseFuncList[DateTimeParseResult]{
 		dp.parseToday,
 		dp.parseYesterday,
 		dp.parseDayBeforeYesterday,
 		dp.parseNextDay,
 		dp.parseDayAfterNextDay,
+		dp.parseDayOffset,
 		dp.parseWeekday,
 		dp.parseLastWeekday,
 		dp.parseNextWeekday,
 		dp.parseWeekAfterNextWeekday,
-		parseAllOf(ParseFuncList[DateTimeParseResult]{dp.parseThisMonth, dp.parseDay}),
-		parseAllOf(ParseFuncList[DateTimeParseResult]{dp.parseLastMonth, dp.parseDay}),
+		dp.allOf(ParseFuncList[DateTimeParseResult]{dp.parseThisMonth, dp.parseDay}),
+		dp.allOf(ParseFuncList[DateTimeParseResult]{dp.parseThisMonth, dp.parseMonthEnd}),
+		dp.allOf(ParseFuncList[DateTimeParseResult]{dp.parseLastMonth, dp.parseDay}),
+		dp.allOf(ParseFuncList[DateTimeParseResult]{dp.parseLastMonth, dp.parseMonthEnd}),
 		dp.parseLastMonth,
-		parseAllOf(ParseFuncList[DateTimeParseResult]{dp.parseNextMonth, dp.parseDay}),
+		dp.allOf(ParseFuncList[DateTimeParseResult]{dp.parseNextMonth, dp.parseDay}),
+		dp.allOf(ParseFuncList[DateTimeParseResult]{dp.parseNextMonth, dp.parseMonthEnd}),
 		dp.parseNextMonth,
-		parseAllOf(ParseFuncList[DateTimeParseResult]{dp.parseLastYear, dp.parseMD}),
+		dp.parseMonthEnd,
+		dp.allOf(ParseFuncList[DateTimeParseResult]{dp.parseLastYear, dp.parseMD}),
 		dp.parseLastYear,
-		parseAllOf(ParseFuncList[DateTimeParseResult]{dp.parseNextYear, dp.parseMD}),
+		dp.allOf(ParseFuncList[DateTimeParseResult]{dp.parseNextYear, dp.parseMD}),
 		dp.parseNextYear,
 		dp.parseYMD,
 		dp.parseMD,
+		dp.allOf(ParseFuncList[DateTimeParseResult]{dp.parseMonth, dp.parseMonthEnd}),
+		dp.parseISOWeekDate,
+		dp.parseISOOrdinalDate,
+		dp.parseNumericDate,
 	})(input, result)
 }
 
+// parseDateOffset parses an offset from the date already in result, such as
+// 的前一天, 之后两天, 后第三天 or 三周后, and moves result by it.
+func (dp *DateTimeParser) parseDateOffset(input string, result *DateTimeParseResult) (string, error) {
+	rest, _ := dp.skipConnectives()(input, result)
+	var sign int
+	var d Duration
+	if next, err := parseDirection(rest, &sign); err == nil {
+		next, _ = parseRegex(next, "第")
+		rest, err = parseDuration(next, &d)
+		if err != nil {
+			return input, err
+		}
+	} else {
+		rest, err = parseDuration(rest, &d)
+		if err != nil {
+			return input, err
+		}
+		rest, err = parseDirection(rest, &sign)
+		if err != nil {
+			return input, err
+		}
+	}
+	if d.Clock != 0 {
+		return input, errors.New("date offset not in whole days")
+	}
+	if sign < 0 {
+		d = d.negate()
+	}
+	n := d.AddTo(time.Date(result.Year, time.Month(result.Month), result.Day, 12, 0, 0, 0, time.UTC))
+	result.Year = n.Year()
+	result.Month = int(n.Month())
+	result.Day = n.Day()
+	return rest, nil
+}
+
+// parseAnyDate parses a date, followed by an offset from it if there is one.
+func (dp *DateTimeParser) parseAnyDate(input string, result *DateTimeParseResult) (string, error) {
+	rest, err := dp.withRule(dp.parseSimpleDate)(input, result)
+	if err != nil {
+		return input, err
+	}
+	if next, err := dp.withRule(dp.parseDateOffset)(rest, result); err == nil {
+		return next, nil
+	}
+	return rest, nil
+}
+
 func (dp *DateTimeParser) parseClockTime(input string, result *DateTimeParseResult) (string, error) {
-	return parseAnyOf(ParseFuncList[DateTimeParseResult]{
+	return dp.anyOf(ParseFuncList[DateTimeParseResult]{
+		dp.parseMeridiemTime,
 		dp.parseNormHourMinute,
+		dp.parseHourLessMinute,
+		dp.parseLessMinuteHour,
 		dp.parseHourMinute,
+		dp.parseNumberHourMore,
 		dp.parseNumberHour,
 	})(input, result)
 }
 
 func (dp *DateTimeParser) parseAnyTime(input string, result *DateTimeParseResult) (string, error) {
-	return parseAnyOf(ParseFuncList[DateTimeParseResult]{
+	return dp.anyOf(ParseFuncList[DateTimeParseResult]{
 		dp.parseAmHourMinute,
 		dp.parsePmHourMinute,
+		dp.parseNoonHourMinute,
 		dp.parseClockTime,
 	})(input, result)
 }
 
+// skipConnectives returns a parser consuming any number of Connectives.
+func (dp *DateTimeParser) skipConnectives() ParseFunc[DateTimeParseResult] {
+	words := dp.Connectives
+	if words == nil {
+		words = DefaultConnectives
+	}
+	return func(input string, _ *DateTimeParseResult) (string, error) {
+		for {
+			rest := input
+			for _, w := range words {
+				if w != "" && strings.HasPrefix(input, w) {
+					rest = input[len(w):]
+					break
+				}
+			}
+			if rest == input {
+				return input, nil
+			}
+			input = rest
+		}
+	}
+}
+
 func (dp *DateTimeParser) parseAnyDateTime(input string, result *DateTimeParseResult) (string, error) {
-	return parseAnyOf(ParseFuncList[DateTimeParseResult]{
-		parseAllOf(ParseFuncList[DateTimeParseResult]{
-			dp.parseAnyDate,
-			dp.parseAnyTime,
-		}),
+	skip := dp.skipConnectives()
+	return dp.allOf(ParseFuncList[DateTimeParseResult]{
+		skip,
+		dp.parseAnyDate,
+		skip,
 		dp.parseAnyTime,
 	})(input, result)
 }
 
-func (dp *DateTimeParser) ParseDateTime(input string) (time.Time, error) {
-	result := DateTimeParseResult{
+func (dp *DateTimeParser) baseResult() DateTimeParseResult {
+	return DateTimeParseResult{
 		Year:   dp.Base.Year(),
 		Month:  int(dp.Base.Month()),
 		Day:    dp.Base.Day(),
@@ -623,30 +1254,125 @@ func (dp *DateTimeParser) ParseDateTime(input string) (time.Time, error) {
 		Minute: 0,
 		Second: 0,
 	}
-	_, err := parseAnyOf(ParseFuncList[DateTimeParseResult]{
-		dp.parseTimePeriod,
+}
+
+// wallTime resolves a wall clock reading in loc. A reading skipped by a
+// forward daylight saving transition is moved forward by the length of the
+// gap, and a reading repeated by a backward transition resolves to the
+// earlier of its two instants.
+func wallTime(year, month, day, hour, min, sec int, loc *time.Location) time.Time {
+	u := time.Date(year, time.Month(month), day, hour, min, sec, 0, time.UTC)
+	_, before := u.Add(-24 * time.Hour).In(loc).Zone()
+	_, after := u.Add(24 * time.Hour).In(loc).Zone()
+	var found []time.Time
+	for _, offset := range []int{before, after} {
+		t := u.Add(-time.Duration(offset) * time.Second).In(loc)
+		if _, o := t.Zone(); o == offset {
+			found = append(found, t)
+		}
+	}
+	if len(found) == 0 {
+		return u.Add(-time.Duration(before) * time.Second).In(loc)
+	}
+	if len(found) == 2 && found[1].Before(found[0]) {
+		return found[1]
+	}
+	return found[0]
+}
+
+func (dp *DateTimeParser) resultTime(result DateTimeParseResult) time.Time {
+	loc := dp.Base.Location()
+	if result.Location != nil {
+		loc = result.Location
+	}
+	var t time.Time
+	if result.hasInstant {
+		t = result.instant.In(loc)
+	} else {
+		t = wallTime(result.Year, result.Month, result.Day, result.Hour, result.Minute, result.Second, loc)
+	}
+	if dp.ConvertToBaseLocation {
+		t = t.In(dp.Base.Location())
+	}
+	return t
+}
+
+func (dp *DateTimeParser) parseAll(input string, result *DateTimeParseResult, f ParseFunc[DateTimeParseResult]) error {
+	rest, err := f(input, result)
+	if err != nil {
+		return err
+	}
+	if dp.Strict && rest != "" {
+		return errors.New("unexpected trailing input " + rest)
+	}
+	return nil
+}
+
+// parseLocalDateTime parses a date and time, an offset or a bare time,
+// optionally hedged as in 大概3点左右. A date with a time is tried before the
+// offsets so that 两天后的上午10点 is not cut short at 两天后, and offsets
+// before bare times so that 2时30分后 is not cut short at 2时30分.
+func (dp *DateTimeParser) parseLocalDateTime(input string, result *DateTimeParseResult) (string, error) {
+	rest, prefixed := parseHedgePrefix(input)
+	rest, err := dp.anyOf(ParseFuncList[DateTimeParseResult]{
 		dp.parseAnyDateTime,
-	})(input, &result)
+		dp.parseTimePeriod,
+		dp.allOf(ParseFuncList[DateTimeParseResult]{
+			dp.skipConnectives(),
+			dp.parseAnyTime,
+		}),
+	})(rest, result)
+	if err != nil {
+		return input, err
+	}
+	rest, suffixed := parseHedgeSuffix(rest)
+	if prefixed || suffixed {
+		dp.hedge(result)
+	}
+	return rest, nil
+}
+
+func (dp *DateTimeParser) parseInput(input string) (DateTimeParseResult, error) {
+	result := dp.baseResult()
+	err := dp.parseAll(normalize(input).text, &result, dp.anyOf(ParseFuncList[DateTimeParseResult]{
+		dp.parseMachineDateTime,
+		dp.parseZonePrefix,
+		dp.withZoneSuffix(dp.withRule(dp.parseLocalDateTime)),
+	}))
+	return result, err
+}
+
+// MatchRules reports the grammar rules ParseDateTime applies to input, in the
+// order they completed.
+func (dp *DateTimeParser) MatchRules(input string) ([]RuleMatch, error) {
+	result, err := dp.parseInput(input)
+	if err != nil {
+		return nil, err
+	}
+	return result.rules.matches(normalize(input)), nil
+}
+
+func (dp *DateTimeParser) ParseDateTime(input string) (time.Time, error) {
+	result, err := dp.parseInput(input)
 	if err != nil {
 		return time.Time{}, err
 	}
-	return time.Date(result.Year, time.Month(result.Month), result.Day, result.Hour, result.Minute, result.Second, 0, dp.Base.Location()), nil
+	return dp.resultTime(result), nil
+}
+
+func (dp *DateTimeParser) parseDateInput(input string) (DateTimeParseResult, error) {
+	result := dp.baseResult()
+	skip := dp.skipConnectives()
+	err := dp.parseAll(normalize(input).text, &result, dp.anyOf(ParseFuncList[DateTimeParseResult]{
+		dp.allOf(ParseFuncList[DateTimeParseResult]{skip, dp.parseAnyDate, skip}),
+	}))
+	return result, err
 }
 
 func (dp *DateTimeParser) ParseDate(input string) (time.Time, error) {
-	result := DateTimeParseResult{
-		Year:   dp.Base.Year(),
-		Month:  int(dp.Base.Month()),
-		Day:    dp.Base.Day(),
-		Hour:   0,
-		Minute: 0,
-		Second: 0,
-	}
-	_, err := parseAnyOf(ParseFuncList[DateTimeParseResult]{
-		dp.parseAnyDate,
-	})(input, &result)
+	result, err := dp.parseDateInput(input)
 	if err != nil {
 		return time.Time{}, err
 	}
-	return time.Date(result.Year, time.Month(result.Month), result.Day, result.Hour, result.Minute, result.Second, 0, dp.Base.Location()), nil
+	return dp.resultTime(result), nil
 }
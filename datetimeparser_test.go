@@ -3,6 +3,7 @@ package datetimeparser
 import (
 	"testing"
 	"time"
+	_ "time/tzdata"
 )
 
 func assert(t *testing.T, value any, expected any, msg string) {
@@ -272,3 +273,523 @@ func TestParseLastYear(t *testing.T) {
 	assert(t, r.Minute(), 24, "minute mismatch")
 	assert(t, r.Second(), 0, "second mismatch")
 }
+
+func TestParseLongestMatch(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 21, 12, 34, 56, 32, shanghai)
+	dateParser := NewDateTimeParser(base)
+	dateParser.LongestMatch = true
+	r, err := dateParser.anyOf(ParseFuncList[DateTimeParseResult]{
+		dateParser.parseLastMonth,
+		parseAllOf(ParseFuncList[DateTimeParseResult]{dateParser.parseLastMonth, dateParser.parseDay}),
+	})("上个月3号", &DateTimeParseResult{})
+	assert(t, err, nil, "error")
+	assert(t, r, "", "rest mismatch")
+	d, err := dateParser.ParseDateTime("去年1月13日上午8点24分")
+	assert(t, err, nil, "error")
+	assert(t, d.Year(), 2021, "year mismatch")
+	assert(t, d.Month(), time.January, "month mismatch")
+	assert(t, d.Day(), 13, "day mismatch")
+	assert(t, d.Hour(), 8, "hour mismatch")
+	assert(t, d.Minute(), 24, "minute mismatch")
+}
+
+func TestParseLongestMatchAmbiguous(t *testing.T) {
+	parseOne := func(input string, r *int) (string, error) {
+		*r = 1
+		return parseRegex(input, "ab")
+	}
+	parseTwo := func(input string, r *int) (string, error) {
+		*r = 2
+		return parseRegex(input, "ab")
+	}
+	var n int
+	_, err := parseLongestOf(ParseFuncList[int]{parseOne, parseTwo}, func(a, b int) bool { return a == b })("abc", &n)
+	amb, ok := err.(*AmbiguousParseError[int])
+	assert(t, ok, true, "ambiguity not reported")
+	assert(t, len(amb.Candidates), 2, "candidates mismatch")
+	assert(t, amb.Rest, "c", "rest mismatch")
+}
+
+func TestParseStrict(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 21, 12, 34, 56, 32, shanghai)
+	dateParser := NewDateTimeParser(base)
+	_, err := dateParser.ParseDateTime("明天上午8点开会")
+	assert(t, err, nil, "error")
+	dateParser.Strict = true
+	_, err = dateParser.ParseDateTime("明天上午8点开会")
+	assert(t, err != nil, true, "trailing input accepted")
+}
+
+func TestMatchRules(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 21, 12, 34, 56, 32, shanghai)
+	dateParser := NewDateTimeParser(base)
+	rules, err := dateParser.MatchRules("明天上午8点")
+	assert(t, err, nil, "error")
+	assert(t, rules[0], RuleMatch{Name: "parseNextDay", Text: "明天", Start: 0, End: 6}, "first rule mismatch")
+	assert(t, rules[len(rules)-1], RuleMatch{Name: "parseLocalDateTime", Text: "明天上午8点", Start: 0, End: 16}, "last rule mismatch")
+	rules, err = dateParser.MatchRules(" 明天， 上午 ８ 点")
+	assert(t, err, nil, "normalized error")
+	assert(t, rules[0], RuleMatch{Name: "parseNextDay", Text: "明天", Start: 1, End: 7}, "normalized first rule mismatch")
+	assert(t, rules[len(rules)-1], RuleMatch{Name: "parseLocalDateTime", Text: "明天， 上午 ８ 点", Start: 1, End: 25}, "normalized last rule mismatch")
+}
+
+func TestParseNormalizedInput(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 21, 12, 34, 56, 32, shanghai)
+	dateParser := NewDateTimeParser(base)
+	dateParser.Strict = true
+	for input, expected := range map[string]time.Time{
+		"明天 下午 3 点":         time.Date(2022, time.August, 22, 15, 0, 0, 0, shanghai),
+		"明天，下午三点":           time.Date(2022, time.August, 22, 15, 0, 0, 0, shanghai),
+		"１５：３０":             time.Date(2022, time.August, 21, 15, 30, 0, 0, shanghai),
+		"2016年 8月 12日 下午3点": time.Date(2016, time.August, 12, 15, 0, 0, 0, shanghai),
+		"２０１６－０８－１２　１５：３０":  time.Date(2016, time.August, 12, 15, 30, 0, 0, shanghai),
+		"  明天上午8点。":         time.Date(2022, time.August, 22, 8, 0, 0, 0, shanghai),
+	} {
+		r, err := dateParser.ParseDateTime(input)
+		assert(t, err, nil, input+" error")
+		assert(t, r.Equal(expected), true, input+" mismatch")
+	}
+	r, err := dateParser.ParseDate("2016年 8月 12日")
+	assert(t, err, nil, "date error")
+	assert(t, r.Day(), 12, "date mismatch")
+}
+
+func TestParseHourPeriodAcrossMidnight(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 20, 22, 34, 56, 32, shanghai)
+	dateParser := NewDateTimeParser(base)
+	r, err := dateParser.ParseDateTime("五小时后")
+	assert(t, err, nil, "error")
+	assert(t, r.Day(), 21, "day mismatch")
+	assert(t, r.Hour(), 3, "hour mismatch")
+	assert(t, r.Minute(), 34, "minute mismatch")
+}
+
+func TestParsePeriodAcrossDSTGap(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	base := time.Date(2022, time.March, 13, 0, 30, 0, 0, newYork)
+	dateParser := NewDateTimeParser(base)
+	r, err := dateParser.ParseDateTime("两小时后")
+	assert(t, err, nil, "error")
+	assert(t, r.Sub(base), 2*time.Hour, "elapsed mismatch")
+	assert(t, r.Hour(), 3, "hour mismatch")
+	assert(t, r.Minute(), 30, "minute mismatch")
+}
+
+func TestParsePeriodAcrossDSTOverlap(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	base := time.Date(2022, time.November, 6, 0, 30, 0, 0, newYork)
+	dateParser := NewDateTimeParser(base)
+	r, err := dateParser.ParseDateTime("一小时后")
+	assert(t, err, nil, "error")
+	assert(t, r.Sub(base), time.Hour, "elapsed mismatch")
+	r, err = dateParser.ParseDateTime("两小时后")
+	assert(t, err, nil, "error")
+	assert(t, r.Sub(base), 2*time.Hour, "elapsed mismatch")
+	assert(t, r.Hour(), 1, "hour mismatch")
+	assert(t, r.Minute(), 30, "minute mismatch")
+}
+
+func TestParseWallClockInDSTGap(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	base := time.Date(2022, time.March, 12, 12, 0, 0, 0, newYork)
+	dateParser := NewDateTimeParser(base)
+	r, err := dateParser.ParseDateTime("明天2点30分")
+	assert(t, err, nil, "error")
+	assert(t, r.Day(), 13, "day mismatch")
+	assert(t, r.Hour(), 3, "hour mismatch")
+	assert(t, r.Minute(), 30, "minute mismatch")
+}
+
+func TestParseWallClockInDSTOverlap(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	base := time.Date(2022, time.November, 5, 12, 0, 0, 0, newYork)
+	dateParser := NewDateTimeParser(base)
+	r, err := dateParser.ParseDateTime("明天1点30分")
+	assert(t, err, nil, "error")
+	_, offset := r.Zone()
+	assert(t, offset, -4*3600, "offset mismatch")
+	assert(t, r.Hour(), 1, "hour mismatch")
+	assert(t, r.Minute(), 30, "minute mismatch")
+}
+
+func TestParseYesterdayIntoMidnightTransition(t *testing.T) {
+	santiago, _ := time.LoadLocation("America/Santiago")
+	base := time.Date(2022, time.September, 12, 0, 30, 0, 0, santiago)
+	dateParser := NewDateTimeParser(base)
+	dateParser.DayBoundary = -1
+	r, err := dateParser.ParseDate("昨天")
+	assert(t, err, nil, "error")
+	assert(t, r.Day(), 11, "day mismatch")
+}
+
+func TestParseNumericDate(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 21, 12, 34, 56, 32, shanghai)
+	dateParser := NewDateTimeParser(base)
+	for _, input := range []string{"2016-08-12", "2016/8/12", "2016.08.12", "20160812"} {
+		r, err := dateParser.ParseDate(input)
+		assert(t, err, nil, input+" error")
+		assert(t, r.Year(), 2016, input+" year mismatch")
+		assert(t, r.Month(), time.August, input+" month mismatch")
+		assert(t, r.Day(), 12, input+" day mismatch")
+	}
+	_, err := dateParser.ParseDate("2016-08/12")
+	assert(t, err != nil, true, "inconsistent separators accepted")
+}
+
+func TestParseNumericDateTime(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 21, 12, 34, 56, 32, shanghai)
+	dateParser := NewDateTimeParser(base)
+	r, err := dateParser.ParseDateTime("2016/8/12 晚上8点")
+	assert(t, err, nil, "error")
+	assert(t, r.Year(), 2016, "year mismatch")
+	assert(t, r.Day(), 12, "day mismatch")
+	assert(t, r.Hour(), 20, "hour mismatch")
+	r, err = dateParser.ParseDateTime("2016-08-12 15:04")
+	assert(t, err, nil, "error")
+	assert(t, r.Hour(), 15, "hour mismatch")
+	assert(t, r.Minute(), 4, "minute mismatch")
+	dateParser.Strict = true
+	r, err = dateParser.ParseDateTime("2016-08-12 15:04:05")
+	assert(t, err, nil, "error")
+	assert(t, r.Equal(time.Date(2016, time.August, 12, 15, 4, 5, 0, shanghai)), true, "time mismatch")
+	dateParser.Strict = false
+	r, err = dateParser.ParseDateTime("8/12 下午3点")
+	assert(t, err, nil, "error")
+	assert(t, r.Year(), 2022, "year mismatch")
+	assert(t, r.Month(), time.August, "month mismatch")
+	assert(t, r.Day(), 12, "day mismatch")
+	assert(t, r.Hour(), 15, "hour mismatch")
+}
+
+func TestParseNumericDateOrder(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 21, 12, 34, 56, 32, shanghai)
+	dateParser := NewDateTimeParser(base)
+	dateParser.DateOrder = MDY
+	r, err := dateParser.ParseDate("8/12/2016")
+	assert(t, err, nil, "error")
+	assert(t, r.Month(), time.August, "month mismatch")
+	assert(t, r.Day(), 12, "day mismatch")
+	dateParser.DateOrder = DMY
+	r, err = dateParser.ParseDate("12.08.2016")
+	assert(t, err, nil, "error")
+	assert(t, r.Year(), 2016, "year mismatch")
+	assert(t, r.Month(), time.August, "month mismatch")
+	assert(t, r.Day(), 12, "day mismatch")
+	r, err = dateParser.ParseDate("12/8")
+	assert(t, err, nil, "error")
+	assert(t, r.Month(), time.August, "month mismatch")
+	assert(t, r.Day(), 12, "day mismatch")
+	r, err = dateParser.ParseDate("2016-08-12")
+	assert(t, err, nil, "error")
+	assert(t, r.Month(), time.August, "month mismatch")
+}
+
+func TestParseTwoDigitYear(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 21, 12, 34, 56, 32, shanghai)
+	dateParser := NewDateTimeParser(base)
+	for input, year := range map[string]int{
+		"16年8月12日":   2016,
+		"'16年8月12日":  2016,
+		"九九年8月12日":   1999,
+		"零八年8月12日":   2008,
+		"二〇一六年8月12日": 2016,
+		"71年8月12日":   2071,
+		"72年8月12日":   1972,
+		"16-08-12":   2016,
+		"2016年8月12日": 2016,
+	} {
+		r, err := dateParser.ParseDate(input)
+		assert(t, err, nil, input+" error")
+		assert(t, r.Year(), year, input+" year mismatch")
+		assert(t, r.Day(), 12, input+" day mismatch")
+	}
+	dateParser.TwoDigitYearFuture = 10
+	r, err := dateParser.ParseDate("71年8月12日")
+	assert(t, err, nil, "error")
+	assert(t, r.Year(), 1971, "year mismatch")
+	dateParser.TwoDigitYearFuture = 0
+	r, err = dateParser.ParseDate("23年8月12日")
+	assert(t, err, nil, "error")
+	assert(t, r.Year(), 1923, "year mismatch")
+	r, err = dateParser.ParseDate("22年8月12日")
+	assert(t, err, nil, "error")
+	assert(t, r.Year(), 2022, "year mismatch")
+	dateParser.TwoDigitYearFuture = 10
+	dateParser.DateOrder = MDY
+	r, err = dateParser.ParseDate("8/12/99")
+	assert(t, err, nil, "error")
+	assert(t, r.Year(), 1999, "year mismatch")
+	assert(t, r.Day(), 12, "day mismatch")
+}
+
+func TestParseChineseNumber(t *testing.T) {
+	for input, expected := range map[string]int{
+		"十":    10,
+		"十一":   11,
+		"二十":   20,
+		"二十三":  23,
+		"一百零五": 105,
+		"两千":   2000,
+	} {
+		var n int
+		rest, err := parseChineseNumber(input, &n)
+		assert(t, err, nil, input+" error")
+		assert(t, rest, "", input+" rest mismatch")
+		assert(t, n, expected, input+" number mismatch")
+	}
+}
+
+func TestParseChineseCompoundDate(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 21, 12, 34, 56, 32, shanghai)
+	dateParser := NewDateTimeParser(base)
+	r, err := dateParser.ParseDateTime("十一月三十号晚上十一点二十")
+	assert(t, err, nil, "error")
+	assert(t, r.Month(), time.November, "month mismatch")
+	assert(t, r.Day(), 30, "day mismatch")
+	assert(t, r.Hour(), 23, "hour mismatch")
+	assert(t, r.Minute(), 20, "minute mismatch")
+}
+
+func TestParsePastPeriod(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 1, 0, 34, 56, 32, shanghai)
+	dateParser := NewDateTimeParser(base)
+	for input, expected := range map[string]time.Duration{
+		"两小时前":     -2 * time.Hour,
+		"3小时以前":    -3 * time.Hour,
+		"半小时以前":    -30 * time.Minute,
+		"半个钟头之前":   -30 * time.Minute,
+		"一个半小时前":   -90 * time.Minute,
+		"十分钟之前":    -10 * time.Minute,
+		"两小时三分钟前":  -2*time.Hour - 3*time.Minute,
+		"十分钟之后":    10 * time.Minute,
+		"两小时三分钟以后": 2*time.Hour + 3*time.Minute,
+	} {
+		r, err := dateParser.ParseDateTime(input)
+		assert(t, err, nil, input+" error")
+		assert(t, r.Sub(base), expected, input+" offset mismatch")
+	}
+	r, err := dateParser.ParseDateTime("两小时前")
+	assert(t, err, nil, "error")
+	assert(t, r.Month(), time.July, "month mismatch")
+	assert(t, r.Day(), 31, "day mismatch")
+	assert(t, r.Hour(), 22, "hour mismatch")
+}
+
+func TestParseDayBoundary(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 22, 2, 34, 56, 32, shanghai)
+	dateParser := NewDateTimeParser(base)
+	for input, day := range map[string]int{
+		"今天": 21,
+		"明天": 22,
+		"后天": 23,
+		"昨天": 20,
+		"前天": 19,
+		"周日": 21,
+	} {
+		r, err := dateParser.ParseDate(input)
+		assert(t, err, nil, input+" error")
+		assert(t, r.Day(), day, input+" day mismatch")
+	}
+	dateParser.DayBoundary = -1
+	for input, day := range map[string]int{
+		"今天":  22,
+		"明天":  23,
+		"昨天":  21,
+		"周日":  28,
+		"下周一": 29,
+	} {
+		r, err := dateParser.ParseDate(input)
+		assert(t, err, nil, input+" error")
+		assert(t, r.Day(), day, input+" day mismatch")
+	}
+	dateParser.DayBoundary = 3 * time.Hour
+	r, err := dateParser.ParseDate("明天")
+	assert(t, err, nil, "error")
+	assert(t, r.Day(), 22, "day mismatch")
+	dateParser.DayBoundary = 5 * time.Hour
+	for input, expected := range map[string]time.Time{
+		"8点":   time.Date(2022, time.August, 22, 8, 0, 0, 0, shanghai),
+		"上午8点": time.Date(2022, time.August, 22, 8, 0, 0, 0, shanghai),
+		"今天8点": time.Date(2022, time.August, 21, 8, 0, 0, 0, shanghai),
+	} {
+		r, err = dateParser.ParseDateTime(input)
+		assert(t, err, nil, input+" error")
+		assert(t, r.Equal(expected), true, input+" mismatch")
+	}
+	dateParser = &DateTimeParser{Base: base}
+	r, err = dateParser.ParseDate("今天")
+	assert(t, err, nil, "error")
+	assert(t, r.Day(), 21, "day mismatch")
+}
+
+func TestParseTimeShortOfHour(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 21, 12, 34, 56, 32, shanghai)
+	dateParser := NewDateTimeParser(base)
+	dateParser.Strict = true
+	for input, expected := range map[string][2]int{
+		"差五分三点":     {2, 55},
+		"三点差一刻":     {2, 45},
+		"两点差10分":    {1, 50},
+		"差一刻钟8点":    {7, 45},
+		"下午三点差一刻":   {14, 45},
+		"明天晚上8点差5分": {19, 55},
+		"0点差十分":     {23, 50},
+	} {
+		r, err := dateParser.ParseDateTime(input)
+		assert(t, err, nil, input+" error")
+		assert(t, r.Hour(), expected[0], input+" hour mismatch")
+		assert(t, r.Minute(), expected[1], input+" minute mismatch")
+	}
+	for _, input := range []string{"明天差五分零点", "明天零点差五分"} {
+		r, err := dateParser.ParseDateTime(input)
+		assert(t, err, nil, input+" error")
+		assert(t, r.Equal(time.Date(2022, time.August, 21, 23, 55, 0, 0, shanghai)), true, input+" mismatch")
+	}
+	r, err := dateParser.ParseDateTime("0点差十分")
+	assert(t, err, nil, "error")
+	assert(t, r.Equal(time.Date(2022, time.August, 20, 23, 50, 0, 0, shanghai)), true, "day mismatch")
+}
+
+func TestParseMeridiemSuffix(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 21, 12, 34, 56, 32, shanghai)
+	dateParser := NewDateTimeParser(base)
+	dateParser.Strict = true
+	for input, expected := range map[string][3]int{
+		"3pm":                  {21, 15, 0},
+		"明天3PM":                {22, 15, 0},
+		"下午3PM":                {21, 15, 0},
+		"10:30AM":              {21, 10, 30},
+		"3:30 p.m.":            {21, 15, 30},
+		"3:30 P.M.":            {21, 15, 30},
+		"12am":                 {21, 0, 0},
+		"12 pm":                {21, 12, 0},
+		"明天3点半pm":              {22, 15, 30},
+		"2022-08-22 9:05 a.m.": {22, 9, 5},
+	} {
+		r, err := dateParser.ParseDateTime(input)
+		assert(t, err, nil, input+" error")
+		assert(t, r.Day(), expected[0], input+" day mismatch")
+		assert(t, r.Hour(), expected[1], input+" hour mismatch")
+		assert(t, r.Minute(), expected[2], input+" minute mismatch")
+	}
+	_, err := dateParser.ParseDateTime("15pm")
+	assert(t, err != nil, true, "15pm accepted")
+	cs, err := dateParser.ParseCandidates("3pm")
+	assert(t, err, nil, "candidates error")
+	for _, c := range cs {
+		assert(t, hasRule(c.Rules, "assumePm"), false, "3pm read as afternoon twice")
+	}
+}
+
+func TestParseConnectives(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 21, 12, 34, 56, 32, shanghai)
+	dateParser := NewDateTimeParser(base)
+	dateParser.Strict = true
+	for input, expected := range map[string]time.Time{
+		"在明天的下午3点":    time.Date(2022, time.August, 22, 15, 0, 0, 0, shanghai),
+		"明天的3点钟":      time.Date(2022, time.August, 22, 3, 0, 0, 0, shanghai),
+		"8月12日当天下午3点": time.Date(2022, time.August, 12, 15, 0, 0, 0, shanghai),
+		"在下午4点":       time.Date(2022, time.August, 21, 16, 0, 0, 0, shanghai),
+		"后天那天晚上8点":    time.Date(2022, time.August, 23, 20, 0, 0, 0, shanghai),
+	} {
+		r, err := dateParser.ParseDateTime(input)
+		assert(t, err, nil, input+" error")
+		assert(t, r.Equal(expected), true, input+" mismatch")
+	}
+	for _, input := range []string{"于8月12日", "8月12日那天", "在8月12日"} {
+		r, err := dateParser.ParseDate(input)
+		assert(t, err, nil, input+" error")
+		assert(t, r.Equal(time.Date(2022, time.August, 12, 0, 0, 0, 0, shanghai)), true, input+" mismatch")
+	}
+	dateParser.Connectives = []string{}
+	_, err := dateParser.ParseDateTime("明天的下午3点")
+	assert(t, err != nil, true, "connective skipped when disabled")
+	dateParser.Connectives = []string{"的时候", "的"}
+	_, err = dateParser.ParseDateTime("明天的下午3点")
+	assert(t, err, nil, "custom connective error")
+}
+
+func TestParseDateWithOffset(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 24, 12, 34, 56, 32, shanghai)
+	dateParser := NewDateTimeParser(base)
+	dateParser.Strict = true
+	for input, expected := range map[string]time.Time{
+		"明天的前一天":         time.Date(2022, time.August, 24, 0, 0, 0, 0, shanghai),
+		"明天后一天":          time.Date(2022, time.August, 26, 0, 0, 0, 0, shanghai),
+		"下周三之后两天":        time.Date(2022, time.September, 2, 0, 0, 0, 0, shanghai),
+		"8月12日后第三天":      time.Date(2022, time.August, 15, 0, 0, 0, 0, shanghai),
+		"10月1日前两周":       time.Date(2022, time.September, 17, 0, 0, 0, 0, shanghai),
+		"2022年1月31日后一个月": time.Date(2022, time.March, 3, 0, 0, 0, 0, shanghai),
+		"后天三天后":          time.Date(2022, time.August, 29, 0, 0, 0, 0, shanghai),
+	} {
+		r, err := dateParser.ParseDate(input)
+		assert(t, err, nil, input+" error")
+		assert(t, r.Equal(expected), true, input+" mismatch")
+	}
+	r, err := dateParser.ParseDateTime("明天的前一天下午3点")
+	assert(t, err, nil, "date time error")
+	assert(t, r.Equal(time.Date(2022, time.August, 24, 15, 0, 0, 0, shanghai)), true, "date time mismatch")
+	_, err = dateParser.ParseDate("明天后3小时")
+	assert(t, err != nil, true, "clock offset accepted as date offset")
+}
+
+func TestParseMixedUnitPeriod(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 24, 12, 34, 56, 0, shanghai)
+	dateParser := NewDateTimeParser(base)
+	dateParser.Strict = true
+	for input, expected := range map[string]time.Time{
+		"1天3小时后":     base.AddDate(0, 0, 1).Add(3 * time.Hour),
+		"三小时二十分钟后":   base.Add(3*time.Hour + 20*time.Minute),
+		"2小时30分20秒前": base.Add(-2*time.Hour - 30*time.Minute - 20*time.Second),
+		"3天后":        base.AddDate(0, 0, 3),
+		"2时30分后":     base.Add(2*time.Hour + 30*time.Minute),
+		"一个半小时后":     base.Add(90 * time.Minute),
+		"半小时前":       base.Add(-30 * time.Minute),
+		"两周后":        base.AddDate(0, 0, 14),
+		"两天后的上午10点":  time.Date(2022, time.August, 26, 10, 0, 0, 0, shanghai),
+		"三天前晚上8点":    time.Date(2022, time.August, 21, 20, 0, 0, 0, shanghai),
+		"一个月后的15:30": time.Date(2022, time.September, 24, 15, 30, 0, 0, shanghai),
+	} {
+		r, err := dateParser.ParseDateTime(input)
+		assert(t, err, nil, input+" error")
+		assert(t, r.Equal(expected), true, input+" mismatch")
+	}
+	r, err := dateParser.ParseDate("两天后")
+	assert(t, err, nil, "date error")
+	assert(t, r.Equal(time.Date(2022, time.August, 26, 0, 0, 0, 0, shanghai)), true, "date mismatch")
+}
+
+func TestParseWeekdayFollowedByHour(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 24, 12, 34, 56, 32, shanghai)
+	dateParser := NewDateTimeParser(base)
+	dateParser.Strict = true
+	for input, expected := range map[string]time.Time{
+		"周二十点":  time.Date(2022, time.August, 23, 10, 0, 0, 0, shanghai),
+		"周一十点半": time.Date(2022, time.August, 22, 10, 30, 0, 0, shanghai),
+		"星期三十点": time.Date(2022, time.August, 24, 10, 0, 0, 0, shanghai),
+		"下周二十点": time.Date(2022, time.August, 30, 10, 0, 0, 0, shanghai),
+		"周六十一点": time.Date(2022, time.August, 27, 11, 0, 0, 0, shanghai),
+	} {
+		r, err := dateParser.ParseDateTime(input)
+		assert(t, err, nil, input+" error")
+		assert(t, r.Equal(expected), true, input+" mismatch")
+	}
+}
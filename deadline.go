@@ -0,0 +1,68 @@
+package datetimeparser
+
+import (
+	"errors"
+	"time"
+)
+
+// BoundDirection says which side of its anchor a Bound extends to.
+type BoundDirection int
+
+const (
+	// BoundBefore is everything up to the anchor, as in 周五前.
+	BoundBefore BoundDirection = iota
+	// BoundAfter is everything from the anchor on, as in 3点以后.
+	BoundAfter
+)
+
+// Bound is an open-ended interval attached to a date or time, such as a
+// deadline. The open side of Interval is the zero time.
+type Bound struct {
+	Direction BoundDirection
+	// Anchor is the date or time the bound is attached to.
+	Anchor time.Time
+	// Interval is the span the bound covers. An anchor without a time of
+	// day includes the whole day, so 周五前 runs to the end of Friday.
+	Interval Interval
+}
+
+func (dp *DateTimeParser) parseBoundAnchor(input string, result *DateTimeParseResult) (string, error) {
+	return dp.anyOf(ParseFuncList[DateTimeParseResult]{
+		dp.parseMachineDateTime,
+		dp.parseZonePrefix,
+		dp.withZoneSuffix(dp.withRule(dp.parseLocalDateTime)),
+		dp.parseAnyDate,
+	})(input, result)
+}
+
+// ParseBound parses a date or time followed by 之前, 以前 or 前, or by 之后, 以后
+// or 后, such as 明天之前, 周五以前, 月底前 or 3点后.
+func (dp *DateTimeParser) ParseBound(input string) (Bound, error) {
+	result := dp.baseResult()
+	rest, err := dp.parseBoundAnchor(normalize(input).text, &result)
+	if err != nil {
+		return Bound{}, err
+	}
+	var direction int
+	rest, err = parseDirection(rest, &direction)
+	if err != nil {
+		return Bound{}, errors.New("bound not parsed")
+	}
+	if dp.Strict && rest != "" {
+		return Bound{}, errors.New("unexpected trailing input " + rest)
+	}
+	anchor := dp.resultTime(result)
+	limit := anchor
+	if !hasRule(result.rules.names(), "parseAnyTime", "parseTimePeriod", "parseMachineDateTime") {
+		loc := anchor.Location()
+		anchor = wallTime(result.Year, result.Month, result.Day, 0, 0, 0, loc)
+		limit = anchor
+		if direction < 0 {
+			limit = wallTime(result.Year, result.Month, result.Day+1, 0, 0, 0, loc)
+		}
+	}
+	if direction < 0 {
+		return Bound{Direction: BoundBefore, Anchor: anchor, Interval: Interval{End: limit}}, nil
+	}
+	return Bound{Direction: BoundAfter, Anchor: anchor, Interval: Interval{Start: limit}}, nil
+}
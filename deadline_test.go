@@ -0,0 +1,53 @@
+package datetimeparser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseBound(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 24, 12, 34, 56, 0, shanghai)
+	dateParser := NewDateTimeParser(base)
+	date := func(m time.Month, d int, h int) time.Time {
+		return time.Date(2022, m, d, h, 0, 0, 0, shanghai)
+	}
+	for input, expected := range map[string]Bound{
+		"明天之前":     {BoundBefore, date(time.August, 25, 0), Interval{End: date(time.August, 26, 0)}},
+		"周五前提交":    {BoundBefore, date(time.August, 26, 0), Interval{End: date(time.August, 27, 0)}},
+		"周五以前":     {BoundBefore, date(time.August, 26, 0), Interval{End: date(time.August, 27, 0)}},
+		"月底前":      {BoundBefore, date(time.August, 31, 0), Interval{End: date(time.September, 1, 0)}},
+		"下个月底前":    {BoundBefore, date(time.September, 30, 0), Interval{End: date(time.October, 1, 0)}},
+		"3点前":      {BoundBefore, date(time.August, 24, 3), Interval{End: date(time.August, 24, 3)}},
+		"明天下午3点之后": {BoundAfter, date(time.August, 25, 15), Interval{Start: date(time.August, 25, 15)}},
+		"周五以后":     {BoundAfter, date(time.August, 26, 0), Interval{Start: date(time.August, 26, 0)}},
+		"3时以后":     {BoundAfter, date(time.August, 24, 3), Interval{Start: date(time.August, 24, 3)}},
+	} {
+		b, err := dateParser.ParseBound(input)
+		assert(t, err, nil, input+" error")
+		assert(t, b.Direction, expected.Direction, input+" direction mismatch")
+		assert(t, b.Anchor.Equal(expected.Anchor), true, input+" anchor mismatch")
+		assert(t, b.Interval.Start.Equal(expected.Interval.Start), true, input+" start mismatch")
+		assert(t, b.Interval.End.Equal(expected.Interval.End), true, input+" end mismatch")
+	}
+	for _, input := range []string{"3天前", "明天", "前"} {
+		_, err := dateParser.ParseBound(input)
+		assert(t, err != nil, true, input+" should not parse")
+	}
+}
+
+func TestParseMonthEnd(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2024, time.January, 20, 12, 34, 56, 0, shanghai)
+	dateParser := NewDateTimeParser(base)
+	for input, expected := range map[string]time.Time{
+		"月底":   time.Date(2024, time.January, 31, 0, 0, 0, 0, shanghai),
+		"下个月底": time.Date(2024, time.February, 29, 0, 0, 0, 0, shanghai),
+		"本月末":  time.Date(2024, time.January, 31, 0, 0, 0, 0, shanghai),
+		"4月底":  time.Date(2024, time.April, 30, 0, 0, 0, 0, shanghai),
+	} {
+		r, err := dateParser.ParseDate(input)
+		assert(t, err, nil, input+" error")
+		assert(t, r.Equal(expected), true, input+" mismatch")
+	}
+}
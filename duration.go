@@ -0,0 +1,159 @@
+package datetimeparser
+
+import (
+	"errors"
+	"regexp"
+	"time"
+)
+
+// Duration is a span of time parsed from text. Years, Months and Days are
+// calendar units whose real length depends on where the span is applied;
+// Clock is the fixed length part.
+type Duration struct {
+	Years  int
+	Months int
+	Days   int
+	Clock  time.Duration
+}
+
+// AddTo returns t moved by d. The calendar units move the wall clock date,
+// then Clock is added as elapsed time.
+func (d Duration) AddTo(t time.Time) time.Time {
+	c := wallTime(t.Year()+d.Years, int(t.Month())+d.Months, t.Day()+d.Days, t.Hour(), t.Minute(), t.Second(), t.Location())
+	return c.Add(time.Duration(t.Nanosecond()) + d.Clock)
+}
+
+func (d Duration) negate() Duration {
+	return Duration{Years: -d.Years, Months: -d.Months, Days: -d.Days, Clock: -d.Clock}
+}
+
+func (d Duration) times(n int) Duration {
+	return Duration{Years: d.Years * n, Months: d.Months * n, Days: d.Days * n, Clock: d.Clock * time.Duration(n)}
+}
+
+// Duration approximates d as a fixed length, counting a day as 24 hours, a
+// month as 30 days and a year as 365 days.
+func (d Duration) Duration() time.Duration {
+	days := d.Years*365 + d.Months*30 + d.Days
+	return time.Duration(days)*24*time.Hour + d.Clock
+}
+
+func parseDurationUnit(unit string, f func(n int, d *Duration)) ParseFunc[Duration] {
+	return func(input string, d *Duration) (string, error) {
+		var n int
+		rest, err := parseNumberWithUnit(input, unit, &n)
+		if err != nil {
+			return input, err
+		}
+		f(n, d)
+		return rest, nil
+	}
+}
+
+func parseHalfDurationUnit(unit string, f func(d *Duration)) ParseFunc[Duration] {
+	return func(input string, d *Duration) (string, error) {
+		rest, err := parseRegex(input, unit)
+		if err != nil {
+			return input, err
+		}
+		f(d)
+		return rest, nil
+	}
+}
+
+var latinDurationRegex = regexp.MustCompile(`^(\d+)d|^(\d+(\.\d+)?(h|ms|m|s))+`)
+
+func parseLatinDuration(input string, d *Duration) (string, error) {
+	rest := input
+	parsed := false
+	for {
+		m := latinDurationRegex.FindStringSubmatch(rest)
+		if m == nil {
+			break
+		}
+		if m[1] != "" {
+			d.Days += atoi(m[1])
+		} else {
+			c, err := time.ParseDuration(m[0])
+			if err != nil {
+				return input, err
+			}
+			d.Clock += c
+		}
+		rest = rest[len(m[0]):]
+		parsed = true
+	}
+	if !parsed {
+		return input, errors.New("latin duration not parsed")
+	}
+	return rest, nil
+}
+
+// parseHourMinuteDuration parses hours and minutes written as 2时30分. A bare
+// 时 reads as a clock hour, so it only counts as a span when minutes follow.
+func parseHourMinuteDuration(input string, d *Duration) (string, error) {
+	var h, m int
+	rest, err := parseNumberWithUnit(input, "时", &h)
+	if err != nil {
+		return input, err
+	}
+	rest, err = parseNumberWithUnit(rest, "分(钟)?", &m)
+	if err != nil {
+		return input, err
+	}
+	d.Clock += time.Duration(h)*time.Hour + time.Duration(m)*time.Minute
+	return rest, nil
+}
+
+func parseDurationComponent(input string, d *Duration) (string, error) {
+	return parseAnyOf(ParseFuncList[Duration]{
+		parseLatinDuration,
+		parseHalfDurationUnit("半(个)?(小时|钟头)", func(d *Duration) { d.Clock += 30 * time.Minute }),
+		parseDurationUnit("个半(小时|钟头)", func(n int, d *Duration) { d.Clock += time.Duration(n)*time.Hour + 30*time.Minute }),
+		parseHourMinuteDuration,
+		parseDurationUnit("(个)?(小时|钟头)", func(n int, d *Duration) { d.Clock += time.Duration(n) * time.Hour }),
+		parseDurationUnit("刻(钟)?", func(n int, d *Duration) { d.Clock += time.Duration(n) * 15 * time.Minute }),
+		parseHalfDurationUnit("半分(钟)?", func(d *Duration) { d.Clock += 30 * time.Second }),
+		parseDurationUnit("分(钟)?", func(n int, d *Duration) { d.Clock += time.Duration(n) * time.Minute }),
+		parseDurationUnit("秒(钟)?", func(n int, d *Duration) { d.Clock += time.Duration(n) * time.Second }),
+		parseHalfDurationUnit("半天", func(d *Duration) { d.Clock += 12 * time.Hour }),
+		parseDurationUnit("(天|日)", func(n int, d *Duration) { d.Days += n }),
+		parseDurationUnit("(个)?(周|星期|礼拜)", func(n int, d *Duration) { d.Days += n * 7 }),
+		parseHalfDurationUnit("半(个)?月", func(d *Duration) { d.Days += 15 }),
+		parseDurationUnit("个半月", func(n int, d *Duration) { d.Months += n; d.Days += 15 }),
+		parseDurationUnit("个月", func(n int, d *Duration) { d.Months += n }),
+		parseHalfDurationUnit("半年", func(d *Duration) { d.Months += 6 }),
+		parseDurationUnit("年半", func(n int, d *Duration) { d.Years += n; d.Months += 6 }),
+		parseDurationUnit("年", func(n int, d *Duration) { d.Years += n }),
+	})(input, d)
+}
+
+// parseDuration parses one or more duration components in sequence, as in
+// 三天两小时.
+func parseDuration(input string, d *Duration) (string, error) {
+	rest, err := parseDurationComponent(input, d)
+	if err != nil {
+		return input, err
+	}
+	for {
+		next, err := parseDurationComponent(rest, d)
+		if err != nil {
+			return rest, nil
+		}
+		rest = next
+	}
+}
+
+// ParseDuration parses a span of time such as 一个半小时, 三天两小时, 两周 or
+// 1h30m.
+func (dp *DateTimeParser) ParseDuration(input string) (Duration, error) {
+	var d Duration
+	rest, err := parseDuration(normalize(input).text, &d)
+	if err != nil {
+		return Duration{}, err
+	}
+	if dp.Strict && rest != "" {
+		return Duration{}, errors.New("unexpected trailing input " + rest)
+	}
+	return d, nil
+}
@@ -0,0 +1,41 @@
+package datetimeparser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	dateParser := NewDateTimeParser(time.Now())
+	for input, expected := range map[string]Duration{
+		"一个半小时":   {Clock: 90 * time.Minute},
+		"半个钟头":    {Clock: 30 * time.Minute},
+		"三天两小时":   {Days: 3, Clock: 2 * time.Hour},
+		"一刻钟":     {Clock: 15 * time.Minute},
+		"半分钟":     {Clock: 30 * time.Second},
+		"两周":      {Days: 14},
+		"1h30m":   {Clock: 90 * time.Minute},
+		"2d3h":    {Days: 2, Clock: 3 * time.Hour},
+		"三个月":     {Months: 3},
+		"一年半":     {Years: 1, Months: 6},
+		"两小时三十分钟": {Clock: 2*time.Hour + 30*time.Minute},
+		"二十五秒":    {Clock: 25 * time.Second},
+		"2时30分":   {Clock: 2*time.Hour + 30*time.Minute},
+	} {
+		d, err := dateParser.ParseDuration(input)
+		assert(t, err, nil, input+" error")
+		assert(t, d, expected, input+" duration mismatch")
+	}
+	_, err := dateParser.ParseDuration("明天")
+	assert(t, err != nil, true, "date accepted as duration")
+	_, err = dateParser.ParseDuration("3时")
+	assert(t, err != nil, true, "clock hour accepted as duration")
+}
+
+func TestDurationAddTo(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.January, 31, 12, 0, 0, 0, shanghai)
+	d := Duration{Months: 1, Clock: 90 * time.Minute}
+	assert(t, d.AddTo(base).Equal(time.Date(2022, time.March, 3, 13, 30, 0, 0, shanghai)), true, "time mismatch")
+	assert(t, Duration{Days: 1, Clock: time.Hour}.Duration(), 25*time.Hour, "duration mismatch")
+}
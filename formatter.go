@@ -0,0 +1,187 @@
+package datetimeparser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formatter renders times as the Chinese expressions a DateTimeParser reads,
+// relative to the parser's Base. Of the phrasings it considers, from most to
+// least natural, it picks the first that the parser resolves back to the
+// same time.
+type Formatter struct {
+	Parser *DateTimeParser
+	// Hour24 writes clock times on the 24 hour clock without 上午/下午.
+	Hour24 bool
+	// ChineseNumerals writes numbers in Chinese characters rather than
+	// Arabic digits.
+	ChineseNumerals bool
+}
+
+func NewFormatter(dp *DateTimeParser) *Formatter {
+	return &Formatter{
+		Parser: dp,
+	}
+}
+
+var chineseNumeralNames = []string{"零", "一", "二", "三", "四", "五", "六", "七", "八", "九"}
+
+var chineseYearDigitNames = []string{"〇", "一", "二", "三", "四", "五", "六", "七", "八", "九"}
+
+var weekdayNames = []string{"日", "一", "二", "三", "四", "五", "六"}
+
+// formatChineseNumber writes n, which must be below 10000, with multipliers
+// as parseChineseNumber reads it.
+func formatChineseNumber(n int) string {
+	if n == 0 {
+		return chineseNumeralNames[0]
+	}
+	var b strings.Builder
+	zero := false
+	for _, u := range []struct {
+		value int
+		name  string
+	}{{1000, "千"}, {100, "百"}, {10, "十"}, {1, ""}} {
+		d := n / u.value % 10
+		if d == 0 {
+			zero = b.Len() > 0
+			continue
+		}
+		if zero {
+			b.WriteString(chineseNumeralNames[0])
+			zero = false
+		}
+		b.WriteString(chineseNumeralNames[d] + u.name)
+	}
+	if n >= 10 && n < 20 {
+		return strings.TrimPrefix(b.String(), "一")
+	}
+	return b.String()
+}
+
+func (f *Formatter) number(n int) string {
+	if f.ChineseNumerals {
+		return formatChineseNumber(n)
+	}
+	return strconv.Itoa(n)
+}
+
+func (f *Formatter) year(y int) string {
+	if !f.ChineseNumerals {
+		return strconv.Itoa(y) + "年"
+	}
+	var b strings.Builder
+	for _, c := range strconv.Itoa(y) {
+		b.WriteString(chineseYearDigitNames[c-'0'])
+	}
+	return b.String() + "年"
+}
+
+func (f *Formatter) hour(h int) string {
+	if f.ChineseNumerals && h == 2 {
+		return "两"
+	}
+	return f.number(h)
+}
+
+func (f *Formatter) clock(t time.Time) string {
+	h, m := t.Hour(), t.Minute()
+	if f.Hour24 && !f.ChineseNumerals {
+		return fmt.Sprintf("%d:%02d", h, m)
+	}
+	period := ""
+	if !f.Hour24 {
+		switch {
+		case h < 6:
+			period = "凌晨"
+		case h < 12:
+			period = "上午"
+		case h == 12:
+			period = "中午"
+		case h < 18:
+			period = "下午"
+		default:
+			period = "晚上"
+		}
+		if h > 12 {
+			h -= 12
+		}
+	}
+	s := period + f.hour(h) + "点"
+	switch m {
+	case 0:
+	case 30:
+		s += "半"
+	default:
+		s += f.number(m) + "分"
+	}
+	return s
+}
+
+func (f *Formatter) period(d time.Duration) string {
+	suffix := "后"
+	if d < 0 {
+		d, suffix = -d, "前"
+	}
+	h, m := int(d/time.Hour), int(d%time.Hour/time.Minute)
+	switch {
+	case h == 0:
+		return f.number(m) + "分钟" + suffix
+	case m == 0:
+		return f.hour(h) + "小时" + suffix
+	}
+	return f.hour(h) + "小时" + f.number(m) + "分钟" + suffix
+}
+
+func (f *Formatter) dates(t time.Time) []string {
+	var dates []string
+	for _, w := range relativeDays {
+		dates = append(dates, w.name)
+	}
+	for _, prefix := range []string{"", "下", "上", "下下"} {
+		dates = append(dates, prefix+"周"+weekdayNames[t.Weekday()])
+	}
+	md := f.number(int(t.Month())) + "月" + f.number(t.Day()) + "日"
+	if t.Year() == f.Parser.Base.Year() {
+		dates = append(dates, md)
+	}
+	return append(dates, f.year(t.Year())+md)
+}
+
+// Format renders t, choosing between a period relative to Base such as
+// 10分钟后, a relative day such as 明天下午3点 or 下周一上午10点半, and an
+// absolute date.
+func (f *Formatter) Format(t time.Time) string {
+	base := f.Parser.Base
+	t = t.In(base.Location())
+	d := t.Sub(base)
+	var phrases []string
+	abs := d
+	if abs < 0 {
+		abs = -abs
+	}
+	if d != 0 && d%time.Minute == 0 {
+		if abs < time.Hour || (abs < 24*time.Hour && (t.Second() != 0 || t.Nanosecond() != 0)) {
+			phrases = append(phrases, f.period(d))
+		}
+	}
+	if d%(24*time.Hour) == 0 && abs >= 3*24*time.Hour {
+		suffix := "后"
+		if d < 0 {
+			suffix = "前"
+		}
+		phrases = append(phrases, f.number(int(abs/(24*time.Hour)))+"天"+suffix)
+	}
+	clock := f.clock(t)
+	for _, date := range f.dates(t) {
+		phrases = append(phrases, date+clock)
+	}
+	for _, p := range phrases {
+		if r, err := f.Parser.ParseDateTime(p); err == nil && r.Equal(t) {
+			return p
+		}
+	}
+	return phrases[len(phrases)-1]
+}
@@ -0,0 +1,65 @@
+package datetimeparser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormat(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 20, 12, 34, 56, 32, shanghai)
+	formatter := NewFormatter(NewDateTimeParser(base))
+	for expected, tm := range map[string]time.Time{
+		"明天下午3点":            time.Date(2022, time.August, 21, 15, 0, 0, 0, shanghai),
+		"下周二上午10点半":         time.Date(2022, time.August, 23, 10, 30, 0, 0, shanghai),
+		"今天上午8点":            time.Date(2022, time.August, 20, 8, 0, 0, 0, shanghai),
+		"今天中午12点":           time.Date(2022, time.August, 20, 12, 0, 0, 0, shanghai),
+		"10分钟后":             base.Add(10 * time.Minute),
+		"2小时5分钟前":           base.Add(-2*time.Hour - 5*time.Minute),
+		"9月30日晚上11点20分":     time.Date(2022, time.September, 30, 23, 20, 0, 0, shanghai),
+		"2016年8月12日下午3点14分": time.Date(2016, time.August, 12, 15, 14, 0, 0, shanghai),
+	} {
+		assert(t, formatter.Format(tm), expected, "format mismatch")
+	}
+	formatter.ChineseNumerals = true
+	assert(t, formatter.Format(time.Date(2022, time.August, 21, 14, 0, 0, 0, shanghai)), "明天下午两点", "format mismatch")
+	assert(t, formatter.Format(time.Date(2016, time.November, 12, 15, 14, 0, 0, shanghai)), "二〇一六年十一月十二日下午三点十四分", "format mismatch")
+	formatter.ChineseNumerals = false
+	formatter.Hour24 = true
+	assert(t, formatter.Format(time.Date(2022, time.August, 21, 15, 0, 0, 0, shanghai)), "明天15:00", "format mismatch")
+	formatter = NewFormatter(NewDateTimeParser(time.Date(2022, time.August, 24, 12, 34, 56, 32, shanghai)))
+	formatter.Hour24, formatter.ChineseNumerals = true, true
+	assert(t, formatter.Format(time.Date(2022, time.August, 29, 10, 30, 0, 0, shanghai)), "下周一十点半", "format mismatch")
+}
+
+func TestFormatChineseNumber(t *testing.T) {
+	for n, expected := range map[int]string{
+		0:    "零",
+		7:    "七",
+		10:   "十",
+		15:   "十五",
+		20:   "二十",
+		105:  "一百零五",
+		115:  "一百一十五",
+		2016: "二千零一十六",
+	} {
+		assert(t, formatChineseNumber(n), expected, "number mismatch")
+	}
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 20, 12, 34, 56, 32, shanghai)
+	dateParser := NewDateTimeParser(base)
+	formatter := NewFormatter(dateParser)
+	for _, style := range []struct{ hour24, chinese bool }{{false, false}, {true, false}, {false, true}, {true, true}} {
+		formatter.Hour24, formatter.ChineseNumerals = style.hour24, style.chinese
+		start := time.Date(2022, time.August, 10, 0, 0, 0, 0, shanghai)
+		for tm := start; tm.Before(start.AddDate(0, 1, 0)); tm = tm.Add(7*time.Hour + 37*time.Minute) {
+			s := formatter.Format(tm)
+			r, err := dateParser.ParseDateTime(s)
+			assert(t, err, nil, s+" error")
+			assert(t, r.Equal(tm), true, s+" round trip mismatch")
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package datetimeparser
+
+import (
+	"errors"
+	"time"
+)
+
+// An indefinite quantity such as 几 or 若干 is read as fewLow to
+// fewLow+fewSpan units.
+const (
+	fewLow  = 2
+	fewSpan = 2
+)
+
+// hedgeWindow is how uncertain a time of day hedged with 左右, 前后, 大约, 大概
+// or 差不多 is. A hedged offset such as 大概10分钟后 is instead uncertain by a
+// quarter of its distance from Base.
+const hedgeWindow = 15 * time.Minute
+
+// Approximation is a parsed time together with the window it is uncertain
+// within. A time given exactly has a window of zero width.
+type Approximation struct {
+	// Time is the centre of Window.
+	Time   time.Time
+	Window Interval
+}
+
+var fuzzyPeriodUnits = []struct {
+	ex   string
+	unit Duration
+}{
+	{"(个)?(小时|钟头)", Duration{Clock: time.Hour}},
+	{"(分钟|分)", Duration{Clock: time.Minute}},
+	{"(天|日)", Duration{Days: 1}},
+}
+
+// parseFuzzyCount parses 几 or 若干, or a number followed by 多 such as 十多
+// or 一个多, as the range of span units starting at low.
+func parseFuzzyCount(input string, low *int, span *int) (string, error) {
+	if rest, err := parseRegex(input, "(几|若干)"); err == nil {
+		*low, *span = fewLow, fewSpan
+		return rest, nil
+	}
+	rest, err := parseAnyNumber(input, low)
+	if err != nil {
+		return input, err
+	}
+	rest, err = parseRegex(rest, "(个)?多")
+	if err != nil {
+		return input, errors.New("fuzzy count not parsed")
+	}
+	*span = 1
+	if *low >= 10 && *low%10 == 0 {
+		*span = 10
+	}
+	return rest, nil
+}
+
+// parseFuzzyPeriod parses an offset with an indefinite count, such as
+// 几分钟后, 十多分钟前 or 一个多小时后, resolving to the middle of its range.
+func (dp *DateTimeParser) parseFuzzyPeriod(input string, result *DateTimeParseResult) (string, error) {
+	var low, span, sign int
+	rest, err := parseFuzzyCount(input, &low, &span)
+	if err != nil {
+		return input, err
+	}
+	for _, u := range fuzzyPeriodUnits {
+		next, err := parseRegex(rest, u.ex)
+		if err != nil {
+			continue
+		}
+		next, err = parseDirection(next, &sign)
+		if err != nil {
+			return input, err
+		}
+		d := u.unit.times(low + span/2)
+		if span%2 != 0 {
+			d.Clock += u.unit.Duration() / 2
+		}
+		if sign < 0 {
+			d = d.negate()
+		}
+		dp.setInstant(result, d.AddTo(dp.Base))
+		result.uncertainty = u.unit.Duration() * time.Duration(span) / 2
+		return next, nil
+	}
+	return input, errors.New("fuzzy period unit not parsed")
+}
+
+// parseNumberHourMore parses 3点多, somewhere in the hour after 3 o'clock.
+func (dp *DateTimeParser) parseNumberHourMore(input string, result *DateTimeParseResult) (string, error) {
+	var h int
+	rest, err := parseNumberWithUnit(input, "(点|时)多", &h)
+	if err != nil {
+		return input, err
+	}
+	result.Hour = h
+	result.Minute = 30
+	result.uncertainty = 30 * time.Minute
+	return rest, nil
+}
+
+// parseHedgePrefix skips 大约, 大概, 差不多 or 约, reporting whether one was
+// there.
+func parseHedgePrefix(input string) (string, bool) {
+	rest, err := parseRegex(input, "(大约|大概|差不多|约)")
+	return rest, err == nil
+}
+
+// parseHedgeSuffix skips 左右 or 前后, reporting whether one was there.
+func parseHedgeSuffix(input string) (string, bool) {
+	rest, err := parseRegex(input, "(左右|前后)")
+	return rest, err == nil
+}
+
+// hedge marks result as approximate. Grammars that already carry their own
+// uncertainty, such as 3点多, keep it.
+func (dp *DateTimeParser) hedge(result *DateTimeParseResult) {
+	if result.uncertainty != 0 {
+		return
+	}
+	result.uncertainty = hedgeWindow
+	if result.hasInstant {
+		result.uncertainty = result.instant.Sub(dp.Base) / 4
+	}
+	if result.uncertainty < 0 {
+		result.uncertainty = -result.uncertainty
+	}
+}
+
+// ParseApproximate parses input like ParseDateTime and also reports how
+// uncertain it is, so that 下午3点多 is 15:30 give or take half an hour and
+// 几分钟后 is three minutes from Base give or take one.
+func (dp *DateTimeParser) ParseApproximate(input string) (Approximation, error) {
+	result, err := dp.parseInput(input)
+	if err != nil {
+		return Approximation{}, err
+	}
+	t := dp.resultTime(result)
+	return Approximation{
+		Time:   t,
+		Window: Interval{Start: t.Add(-result.uncertainty), End: t.Add(result.uncertainty)},
+	}, nil
+}
@@ -0,0 +1,44 @@
+package datetimeparser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseApproximate(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 20, 12, 34, 56, 0, shanghai)
+	dateParser := NewDateTimeParser(base)
+	dateParser.Strict = true
+	at := func(d int, h int, m int) time.Time {
+		return time.Date(2022, time.August, d, h, m, 0, 0, shanghai)
+	}
+	for input, expected := range map[string]struct {
+		time        time.Time
+		uncertainty time.Duration
+	}{
+		"3点左右":    {at(20, 3, 0), 15 * time.Minute},
+		"大概3点左右":  {at(20, 3, 0), 15 * time.Minute},
+		"十点前后":    {at(20, 10, 0), 15 * time.Minute},
+		"差不多明天8点": {at(21, 8, 0), 15 * time.Minute},
+		"下午3点多":   {at(20, 15, 30), 30 * time.Minute},
+		"大约10分钟后": {base.Add(10 * time.Minute), 150 * time.Second},
+		"几分钟后":    {base.Add(3 * time.Minute), time.Minute},
+		"若干小时前":   {base.Add(-3 * time.Hour), time.Hour},
+		"几天后":     {base.AddDate(0, 0, 3), 24 * time.Hour},
+		"十多分钟前":   {base.Add(-15 * time.Minute), 5 * time.Minute},
+		"一个多小时后":  {base.Add(90 * time.Minute), 30 * time.Minute},
+		"明天上午9点":  {at(21, 9, 0), 0},
+	} {
+		a, err := dateParser.ParseApproximate(input)
+		assert(t, err, nil, input+" error")
+		assert(t, a.Time.Equal(expected.time), true, input+" time mismatch")
+		assert(t, a.Window.Start.Equal(expected.time.Add(-expected.uncertainty)), true, input+" start mismatch")
+		assert(t, a.Window.End.Equal(expected.time.Add(expected.uncertainty)), true, input+" end mismatch")
+		r, err := dateParser.ParseDateTime(input)
+		assert(t, err, nil, input+" error")
+		assert(t, r.Equal(expected.time), true, input+" mismatch")
+	}
+	_, err := dateParser.ParseBound("十点前后")
+	assert(t, err != nil, true, "十点前后 should not be a bound")
+}
@@ -0,0 +1,88 @@
+package datetimeparser
+
+import (
+	"strconv"
+	"time"
+)
+
+// HumanizeThresholds controls how coarse HumanizeRelative gets as a time
+// moves away from the base.
+type HumanizeThresholds struct {
+	// JustNow is the distance below which a time is 刚刚 or 马上.
+	JustNow time.Duration
+	// Minutes is the distance below which a time is counted in minutes.
+	Minutes time.Duration
+	// Hours is the distance below which a time on the same day is counted
+	// in hours.
+	Hours time.Duration
+	// Days is the number of days below which a time is counted in days,
+	// unless it is close enough to be 昨天 or 明天.
+	Days int
+}
+
+var DefaultHumanizeThresholds = HumanizeThresholds{
+	JustNow: time.Minute,
+	Minutes: time.Hour,
+	Hours:   24 * time.Hour,
+	Days:    30,
+}
+
+// HumanizeRelative describes t relative to base using the default
+// thresholds.
+func HumanizeRelative(t time.Time, base time.Time) string {
+	return DefaultHumanizeThresholds.Humanize(t, base)
+}
+
+func relativeCount(n int, unit string) string {
+	if n < 0 {
+		return strconv.Itoa(-n) + unit + "前"
+	}
+	return strconv.Itoa(n) + unit + "后"
+}
+
+// Humanize describes t relative to base in coarse terms such as 刚刚,
+// 5分钟前, 昨天 14:30, 3天前 or 上个月.
+func (h HumanizeThresholds) Humanize(t time.Time, base time.Time) string {
+	t = t.In(base.Location())
+	d := t.Sub(base)
+	abs := d
+	if abs < 0 {
+		abs = -abs
+	}
+	if abs < h.JustNow {
+		if d < 0 {
+			return "刚刚"
+		}
+		return "马上"
+	}
+	if abs < h.Minutes {
+		return relativeCount(int(d/time.Minute), "分钟")
+	}
+	noon := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 12, 0, 0, 0, time.UTC)
+	}
+	days := int(noon(t).Sub(noon(base)).Hours() / 24)
+	if days == 0 && abs < h.Hours {
+		return relativeCount(int(d/time.Hour), "小时")
+	}
+	if w, ok := relativeWord(relativeDays, days); ok {
+		return w.name + " " + t.Format("15:04")
+	}
+	months := (t.Year()-base.Year())*12 + int(t.Month()) - int(base.Month())
+	if days > -h.Days && days < h.Days {
+		return relativeCount(days, "天")
+	}
+	if months == 0 {
+		return "这个月"
+	}
+	if w, ok := relativeWord(relativeMonths, months); ok {
+		return w.name
+	}
+	if months > -12 && months < 12 {
+		return relativeCount(months, "个月")
+	}
+	if w, ok := relativeWord(relativeYears, t.Year()-base.Year()); ok {
+		return w.name
+	}
+	return relativeCount(t.Year()-base.Year(), "年")
+}
@@ -0,0 +1,33 @@
+package datetimeparser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHumanizeRelative(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 20, 18, 34, 56, 0, shanghai)
+	for expected, tm := range map[string]time.Time{
+		"刚刚":       base.Add(-20 * time.Second),
+		"马上":       base.Add(20 * time.Second),
+		"5分钟前":     base.Add(-5 * time.Minute),
+		"10分钟后":    base.Add(10 * time.Minute),
+		"3小时前":     base.Add(-3 * time.Hour),
+		"昨天 14:30": time.Date(2022, time.August, 19, 14, 30, 0, 0, shanghai),
+		"明天 09:00": time.Date(2022, time.August, 21, 9, 0, 0, 0, shanghai),
+		"前天 08:05": time.Date(2022, time.August, 18, 8, 5, 0, 0, shanghai),
+		"3天前":      time.Date(2022, time.August, 17, 8, 0, 0, 0, shanghai),
+		"上个月":      time.Date(2022, time.July, 1, 8, 0, 0, 0, shanghai),
+		"3个月前":     time.Date(2022, time.May, 1, 8, 0, 0, 0, shanghai),
+		"去年":       time.Date(2021, time.March, 1, 8, 0, 0, 0, shanghai),
+		"5年前":      time.Date(2017, time.March, 1, 8, 0, 0, 0, shanghai),
+		"明年":       time.Date(2023, time.December, 1, 8, 0, 0, 0, shanghai),
+	} {
+		assert(t, HumanizeRelative(tm, base), expected, "humanize mismatch")
+	}
+	thresholds := DefaultHumanizeThresholds
+	thresholds.Days = 3
+	assert(t, thresholds.Humanize(time.Date(2022, time.August, 15, 8, 0, 0, 0, shanghai), base), "这个月", "humanize mismatch")
+	assert(t, thresholds.Humanize(time.Date(2022, time.July, 25, 8, 0, 0, 0, shanghai), base), "上个月", "humanize mismatch")
+}
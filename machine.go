@@ -0,0 +1,120 @@
+package datetimeparser
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var isoDateTimeRegex = regexp.MustCompile(`^(\d{4})-(\d{2})-(\d{2})T(\d{2}):(\d{2})(:(\d{2})(\.(\d{1,9}))?)?(Z|([+-])(\d{2})(:?(\d{2}))?)?`)
+
+var isoWeekDateRegex = regexp.MustCompile(`^(\d{4})-?W(\d{2})(-?([1-7]))?`)
+
+var isoOrdinalDateRegex = regexp.MustCompile(`^(\d{4})-(\d{3})(\D|$)`)
+
+var unixTimestampRegex = regexp.MustCompile(`^(\d{13}|\d{10})(\D|$)`)
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// parseISODateTime parses RFC 3339 and ISO 8601 extended date times. A time
+// carrying a UTC offset resolves to that exact instant; one without is read
+// as a wall clock in the base location.
+func (dp *DateTimeParser) parseISODateTime(input string, result *DateTimeParseResult) (string, error) {
+	m := isoDateTimeRegex.FindStringSubmatch(input)
+	if m == nil {
+		return input, errors.New("iso date time not parsed")
+	}
+	y, mo, d, h, mi, s := atoi(m[1]), atoi(m[2]), atoi(m[3]), atoi(m[4]), atoi(m[5]), atoi(m[7])
+	if mo < 1 || mo > 12 || d < 1 || d > 31 || h > 23 || mi > 59 || s > 60 {
+		return input, errors.New("iso date time out of range")
+	}
+	rest := input[len(m[0]):]
+	ns := 0
+	if m[9] != "" {
+		ns = atoi((m[9] + "00000000")[:9])
+	}
+	if m[10] == "" {
+		t := wallTime(y, mo, d, h, mi, s, dp.Base.Location())
+		dp.setInstant(result, t.Add(time.Duration(ns)))
+		return rest, nil
+	}
+	loc := time.UTC
+	if m[10] != "Z" {
+		offset := atoi(m[12])*3600 + atoi(m[14])*60
+		if m[11] == "-" {
+			offset = -offset
+		}
+		loc = time.FixedZone("", offset)
+	}
+	result.Location = loc
+	dp.setInstant(result, time.Date(y, time.Month(mo), d, h, mi, s, ns, loc))
+	return rest, nil
+}
+
+// parseISOWeekDate parses ISO 8601 week dates such as 2016-W32-5. A missing
+// weekday means the Monday of that week.
+func (dp *DateTimeParser) parseISOWeekDate(input string, result *DateTimeParseResult) (string, error) {
+	m := isoWeekDateRegex.FindStringSubmatch(input)
+	if m == nil {
+		return input, errors.New("iso week date not parsed")
+	}
+	y, w, d := atoi(m[1]), atoi(m[2]), 1
+	if m[4] != "" {
+		d = atoi(m[4])
+	}
+	// 4 January is always in week 1.
+	jan4 := time.Date(y, time.January, 4, 12, 0, 0, 0, time.UTC)
+	monday := jan4.AddDate(0, 0, -(int(jan4.Weekday())+6)%7)
+	t := monday.AddDate(0, 0, (w-1)*7+d-1)
+	if _, tw := t.ISOWeek(); w < 1 || tw != w {
+		return input, errors.New("iso week out of range")
+	}
+	result.Year = t.Year()
+	result.Month = int(t.Month())
+	result.Day = t.Day()
+	return input[len(m[0]):], nil
+}
+
+// parseISOOrdinalDate parses ISO 8601 ordinal dates such as 2016-225.
+func (dp *DateTimeParser) parseISOOrdinalDate(input string, result *DateTimeParseResult) (string, error) {
+	m := isoOrdinalDateRegex.FindStringSubmatch(input)
+	if m == nil {
+		return input, errors.New("iso ordinal date not parsed")
+	}
+	y, n := atoi(m[1]), atoi(m[2])
+	t := time.Date(y, time.January, n, 12, 0, 0, 0, time.UTC)
+	if n < 1 || t.Year() != y {
+		return input, errors.New("iso ordinal date out of range")
+	}
+	result.Year = t.Year()
+	result.Month = int(t.Month())
+	result.Day = t.Day()
+	return input[len(m[0])-len(m[3]):], nil
+}
+
+// parseUnixTimestamp parses 10 digit second and 13 digit millisecond epoch
+// timestamps.
+func (dp *DateTimeParser) parseUnixTimestamp(input string, result *DateTimeParseResult) (string, error) {
+	m := unixTimestampRegex.FindStringSubmatch(input)
+	if m == nil {
+		return input, errors.New("unix timestamp not parsed")
+	}
+	n, _ := strconv.ParseInt(m[1], 10, 64)
+	t := time.Unix(n, 0)
+	if len(m[1]) == 13 {
+		t = time.UnixMilli(n)
+	}
+	dp.setInstant(result, t.In(dp.Base.Location()))
+	return input[len(m[0])-len(m[2]):], nil
+}
+
+func (dp *DateTimeParser) parseMachineDateTime(input string, result *DateTimeParseResult) (string, error) {
+	return dp.anyOf(ParseFuncList[DateTimeParseResult]{
+		dp.parseISODateTime,
+		dp.parseUnixTimestamp,
+	})(input, result)
+}
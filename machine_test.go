@@ -0,0 +1,75 @@
+package datetimeparser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRFC3339(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 21, 12, 34, 56, 32, shanghai)
+	dateParser := NewDateTimeParser(base)
+	r, err := dateParser.ParseDateTime("2016-08-12T15:04:05+08:00")
+	assert(t, err, nil, "error")
+	assert(t, r.Equal(time.Date(2016, time.August, 12, 15, 4, 5, 0, shanghai)), true, "time mismatch")
+	r, err = dateParser.ParseDateTime("2016-08-12T07:04:05.250Z")
+	assert(t, err, nil, "error")
+	assert(t, r.Location(), time.UTC, "location mismatch")
+	assert(t, r.Equal(time.Date(2016, time.August, 12, 15, 4, 5, 250000000, shanghai)), true, "time mismatch")
+	r, err = dateParser.ParseDateTime("2016-08-12T15:04")
+	assert(t, err, nil, "error")
+	assert(t, r.Location(), shanghai, "location mismatch")
+	assert(t, r.Hour(), 15, "hour mismatch")
+	assert(t, r.Minute(), 4, "minute mismatch")
+	r, err = dateParser.ParseDateTime("2016-08-12T15:04:05.250")
+	assert(t, err, nil, "error")
+	assert(t, r.Equal(time.Date(2016, time.August, 12, 15, 4, 5, 250000000, shanghai)), true, "time mismatch")
+}
+
+func TestParseInstantFarFromBase(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 21, 12, 34, 56, 32, shanghai)
+	dateParser := NewDateTimeParser(base)
+	for input, expected := range map[string]time.Time{
+		"1700-01-01T00:00:00Z": time.Date(1700, time.January, 1, 0, 0, 0, 0, time.UTC),
+		"2500-01-01T00:00:00Z": time.Date(2500, time.January, 1, 0, 0, 0, 0, time.UTC),
+		"500年后":                base.AddDate(500, 0, 0),
+	} {
+		r, err := dateParser.ParseDateTime(input)
+		assert(t, err, nil, input+" error")
+		assert(t, r.Equal(expected), true, input+" mismatch")
+	}
+}
+
+func TestParseISOWeekAndOrdinalDate(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 21, 12, 34, 56, 32, shanghai)
+	dateParser := NewDateTimeParser(base)
+	r, err := dateParser.ParseDate("2016-W32-5")
+	assert(t, err, nil, "error")
+	assert(t, r.Month(), time.August, "month mismatch")
+	assert(t, r.Day(), 12, "day mismatch")
+	r, err = dateParser.ParseDate("2016-225")
+	assert(t, err, nil, "error")
+	assert(t, r.Month(), time.August, "month mismatch")
+	assert(t, r.Day(), 12, "day mismatch")
+	r, err = dateParser.ParseDateTime("2016-W32-5下午3点")
+	assert(t, err, nil, "error")
+	assert(t, r.Day(), 12, "day mismatch")
+	assert(t, r.Hour(), 15, "hour mismatch")
+	_, err = dateParser.ParseDate("2016-W54")
+	assert(t, err != nil, true, "invalid week accepted")
+}
+
+func TestParseUnixTimestamp(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 21, 12, 34, 56, 32, shanghai)
+	dateParser := NewDateTimeParser(base)
+	r, err := dateParser.ParseDateTime("1470985445")
+	assert(t, err, nil, "error")
+	assert(t, r.Equal(time.Unix(1470985445, 0)), true, "time mismatch")
+	assert(t, r.Location(), shanghai, "location mismatch")
+	r, err = dateParser.ParseDateTime("1470985445123")
+	assert(t, err, nil, "error")
+	assert(t, r.Equal(time.UnixMilli(1470985445123)), true, "time mismatch")
+}
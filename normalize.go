@@ -0,0 +1,95 @@
+package datetimeparser
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// normalized is an input rewritten for the grammars. Full-width forms are
+// folded to ASCII, and whitespace and filler punctuation between tokens are
+// dropped unless they separate two ASCII letters or digits, as in
+// 2016-08-12 15:30 or 3:30 p.m. starts and ends map every byte of text to the
+// span of the original rune it came from.
+type normalized struct {
+	source string
+	text   string
+	starts []int
+	ends   []int
+}
+
+func isFiller(r rune) bool {
+	return unicode.IsSpace(r) || strings.ContainsRune(",，、。;；", r)
+}
+
+func isASCIIAlnum(r rune) bool {
+	return r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// foldWidth maps full-width ASCII variants and the ideographic space to
+// their ASCII equivalents.
+func foldWidth(r rune) rune {
+	switch {
+	case r >= '！' && r <= '～':
+		return r - '！' + '!'
+	case r == '　':
+		return ' '
+	}
+	return r
+}
+
+func normalize(input string) normalized {
+	n := normalized{source: input}
+	var b strings.Builder
+	prev := rune(-1)
+	for i := 0; i < len(input); {
+		r, size := utf8.DecodeRuneInString(input[i:])
+		r = foldWidth(r)
+		if !isFiller(r) {
+			n.add(&b, r, i, i+size)
+			prev = r
+			i += size
+			continue
+		}
+		j := i
+		for j < len(input) {
+			f, size := utf8.DecodeRuneInString(input[j:])
+			if !isFiller(foldWidth(f)) {
+				break
+			}
+			j += size
+		}
+		next, _ := utf8.DecodeRuneInString(input[j:])
+		if j < len(input) && isASCIIAlnum(prev) && isASCIIAlnum(foldWidth(next)) {
+			for i < j {
+				f, size := utf8.DecodeRuneInString(input[i:])
+				n.add(&b, foldWidth(f), i, i+size)
+				i += size
+			}
+		}
+		i = j
+	}
+	n.text = b.String()
+	return n
+}
+
+func (n *normalized) add(b *strings.Builder, r rune, start int, end int) {
+	before := b.Len()
+	b.WriteRune(r)
+	for k := before; k < b.Len(); k++ {
+		n.starts = append(n.starts, start)
+		n.ends = append(n.ends, end)
+	}
+}
+
+// span returns the offsets in source of the text from byte from of the
+// normalized text up to byte to.
+func (n normalized) span(from int, to int) (int, int) {
+	if from >= to {
+		if from < len(n.starts) {
+			return n.starts[from], n.starts[from]
+		}
+		return len(n.source), len(n.source)
+	}
+	return n.starts[from], n.ends[to-1]
+}
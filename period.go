@@ -0,0 +1,266 @@
+package datetimeparser
+
+import (
+	"errors"
+	"regexp"
+	"time"
+)
+
+// Interval is the half-open span of time [Start, End).
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+type periodUnit int
+
+const (
+	periodYear periodUnit = iota
+	periodHalf
+	periodQuarter
+)
+
+// periodResult is a year, half or quarter. Index counts halves and quarters
+// from 1 within Year, which for fiscal periods is the fiscal year's name.
+type periodResult struct {
+	Year   int
+	Unit   periodUnit
+	Index  int
+	Fiscal bool
+}
+
+// FiscalCalendar describes how fiscal years are laid out over calendar years.
+type FiscalCalendar struct {
+	// StartMonth is the first month of the fiscal year; zero means January.
+	StartMonth time.Month
+	// NamedByEndYear names a fiscal year after the calendar year it ends in,
+	// so that with an April start FY24 runs from April 2023 to March 2024.
+	// Otherwise it is named after the year it starts in.
+	NamedByEndYear bool
+}
+
+func (f FiscalCalendar) startMonth() int {
+	if f.StartMonth == 0 {
+		return 1
+	}
+	return int(f.StartMonth)
+}
+
+// startYear returns the calendar year in which the fiscal year named y
+// starts.
+func (f FiscalCalendar) startYear(y int) int {
+	if f.NamedByEndYear && f.startMonth() != 1 {
+		return y - 1
+	}
+	return y
+}
+
+func (u periodUnit) months() int {
+	switch u {
+	case periodHalf:
+		return 6
+	case periodQuarter:
+		return 3
+	}
+	return 12
+}
+
+// shift moves p by n periods of its own unit.
+func (p periodResult) shift(n int) periodResult {
+	perYear := 12 / p.Unit.months()
+	i := p.Year*perYear + p.Index - 1 + n
+	p.Year = i / perYear
+	p.Index = i%perYear + 1
+	return p
+}
+
+func (dp *DateTimeParser) periodInterval(p periodResult) Interval {
+	months := p.Unit.months()
+	month := 1 + (p.Index-1)*months
+	if p.Fiscal {
+		month += dp.Fiscal.startMonth() - 1
+		p.Year = dp.Fiscal.startYear(p.Year)
+	}
+	return Interval{
+		Start: wallTime(p.Year, month, 1, 0, 0, 0, dp.Base.Location()),
+		End:   wallTime(p.Year, month+months, 1, 0, 0, 0, dp.Base.Location()),
+	}
+}
+
+// currentPeriod returns the period of unit u containing Base.
+func (dp *DateTimeParser) currentPeriod(u periodUnit) periodResult {
+	return periodResult{
+		Year:  dp.Base.Year(),
+		Unit:  u,
+		Index: (int(dp.Base.Month())-1)/u.months() + 1,
+	}
+}
+
+// currentFiscalPeriod returns the fiscal period of unit u containing Base.
+func (dp *DateTimeParser) currentFiscalPeriod(u periodUnit) periodResult {
+	m := int(dp.Base.Month()) - dp.Fiscal.startMonth()
+	y := dp.Base.Year()
+	if m < 0 {
+		m += 12
+		y--
+	}
+	// y is the start year; convert it to the fiscal year's name.
+	y += y - dp.Fiscal.startYear(y)
+	return periodResult{
+		Year:   y,
+		Unit:   u,
+		Index:  m/u.months() + 1,
+		Fiscal: true,
+	}
+}
+
+var relativeFiscalYears = []relativePeriod{
+	{"本财年", "(这|今|本)(个)?财年", 0},
+	{"上财年", "上(个)?财年", -1},
+	{"下财年", "下(个)?财年", 1},
+}
+
+var relativeFiscalQuarters = []relativePeriod{
+	{"本财季", "(这|本)(个)?财季", 0},
+	{"上财季", "上(个)?财季", -1},
+	{"下财季", "下(个)?财季", 1},
+}
+
+var relativeQuarters = []relativePeriod{
+	{"本季度", "(这|本)(个)?季度", 0},
+	{"上季度", "上(个)?季度", -1},
+	{"下季度", "下(个)?季度", 1},
+}
+
+func (dp *DateTimeParser) parsePeriodYear(input string, p *periodResult) (string, error) {
+	for _, y := range relativeYears {
+		rest, err := parseRegex(input, y.ex)
+		if err == nil {
+			*p = dp.currentPeriod(periodYear).shift(y.offset)
+			return rest, nil
+		}
+	}
+	var r DateTimeParseResult
+	rest, err := dp.parseYear(input, &r)
+	if err != nil {
+		return input, err
+	}
+	*p = periodResult{Year: r.Year, Unit: periodYear, Index: 1}
+	return rest, nil
+}
+
+var quarterRegex = regexp.MustCompile(`^([Qq]([1-4])|(第)?([1-4一二三四])(个)?季度)`)
+
+func parsePeriodQuarter(input string, p *periodResult) (string, error) {
+	m := quarterRegex.FindStringSubmatch(input)
+	if m == nil {
+		return input, errors.New("quarter not parsed")
+	}
+	q := m[2] + m[4]
+	if _, err := parseAnyNumber(q, &p.Index); err != nil {
+		return input, err
+	}
+	p.Unit = periodQuarter
+	return input[len(m[0]):], nil
+}
+
+func parsePeriodHalf(input string, p *periodResult) (string, error) {
+	rest, err := parseRegex(input, "(上半年|[Hh]1)")
+	if err == nil {
+		p.Unit, p.Index = periodHalf, 1
+		return rest, nil
+	}
+	rest, err = parseRegex(input, "(下半年|[Hh]2)")
+	if err == nil {
+		p.Unit, p.Index = periodHalf, 2
+		return rest, nil
+	}
+	return input, errors.New("half year not parsed")
+}
+
+func (dp *DateTimeParser) parseRelativeQuarter(input string, p *periodResult) (string, error) {
+	for _, q := range relativeQuarters {
+		rest, err := parseRegex(input, q.ex)
+		if err == nil {
+			*p = dp.currentPeriod(periodQuarter).shift(q.offset)
+			return rest, nil
+		}
+	}
+	return input, errors.New("relative quarter not parsed")
+}
+
+var fiscalYearRegex = regexp.MustCompile(`^FY(\d{4}|\d{2})\s*`)
+
+func (dp *DateTimeParser) parseFiscalYear(input string, p *periodResult) (string, error) {
+	for _, y := range relativeFiscalYears {
+		rest, err := parseRegex(input, y.ex)
+		if err == nil {
+			*p = dp.currentFiscalPeriod(periodYear).shift(y.offset)
+			return rest, nil
+		}
+	}
+	*p = periodResult{Unit: periodYear, Index: 1, Fiscal: true}
+	if m := fiscalYearRegex.FindStringSubmatch(input); m != nil {
+		p.Year = atoi(m[1])
+		if len(m[1]) == 2 {
+			p.Year = dp.expandYear(p.Year)
+		}
+		return input[len(m[0]):], nil
+	}
+	rest, err := parseNumericNumber(input, &p.Year)
+	if err != nil {
+		return input, err
+	}
+	rest, err = parseRegex(rest, "财年")
+	if err != nil {
+		return input, err
+	}
+	return rest, nil
+}
+
+func (dp *DateTimeParser) parseRelativeFiscalQuarter(input string, p *periodResult) (string, error) {
+	for _, q := range relativeFiscalQuarters {
+		rest, err := parseRegex(input, q.ex)
+		if err == nil {
+			*p = dp.currentFiscalPeriod(periodQuarter).shift(q.offset)
+			return rest, nil
+		}
+	}
+	return input, errors.New("relative fiscal quarter not parsed")
+}
+
+func (dp *DateTimeParser) parseAnyPeriod(input string, p *periodResult) (string, error) {
+	withCurrentYear := func(f ParseFunc[periodResult]) ParseFunc[periodResult] {
+		return func(input string, p *periodResult) (string, error) {
+			p.Year = dp.Base.Year()
+			return f(input, p)
+		}
+	}
+	return parseAnyOf(ParseFuncList[periodResult]{
+		parseAllOf(ParseFuncList[periodResult]{dp.parseFiscalYear, parsePeriodHalf}),
+		parseAllOf(ParseFuncList[periodResult]{dp.parseFiscalYear, parsePeriodQuarter}),
+		dp.parseFiscalYear,
+		dp.parseRelativeFiscalQuarter,
+		parseAllOf(ParseFuncList[periodResult]{dp.parsePeriodYear, parsePeriodHalf}),
+		parseAllOf(ParseFuncList[periodResult]{dp.parsePeriodYear, parsePeriodQuarter}),
+		dp.parsePeriodYear,
+		dp.parseRelativeQuarter,
+		withCurrentYear(parsePeriodHalf),
+		withCurrentYear(parsePeriodQuarter),
+	})(input, p)
+}
+
+// ParsePeriod parses a year, half year or quarter such as 前年, 今年上半年,
+// 上季度 or 2023年第三季度 and returns the interval it covers. Fiscal periods
+// such as 本财年, 上财季 or FY24 Q2 follow the Fiscal calendar.
+func (dp *DateTimeParser) ParsePeriod(input string) (Interval, error) {
+	var p periodResult
+	rest, err := dp.parseAnyPeriod(normalize(input).text, &p)
+	if err != nil {
+		return Interval{}, err
+	}
+	if dp.Strict && rest != "" {
+		return Interval{}, errors.New("unexpected trailing input " + rest)
+	}
+	return dp.periodInterval(p), nil
+}
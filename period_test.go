@@ -0,0 +1,66 @@
+package datetimeparser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePeriod(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.February, 20, 12, 34, 56, 32, shanghai)
+	dateParser := NewDateTimeParser(base)
+	date := func(y int, m time.Month) time.Time {
+		return time.Date(y, m, 1, 0, 0, 0, 0, shanghai)
+	}
+	for input, expected := range map[string]Interval{
+		"今年":        {date(2022, time.January), date(2023, time.January)},
+		"前年":        {date(2020, time.January), date(2021, time.January)},
+		"今年上半年":     {date(2022, time.January), date(2022, time.July)},
+		"下半年":       {date(2022, time.July), date(2023, time.January)},
+		"去年下半年":     {date(2021, time.July), date(2022, time.January)},
+		"上季度":       {date(2021, time.October), date(2022, time.January)},
+		"本季度":       {date(2022, time.January), date(2022, time.April)},
+		"下个季度":      {date(2022, time.April), date(2022, time.July)},
+		"Q3":        {date(2022, time.July), date(2022, time.October)},
+		"第二季度":      {date(2022, time.April), date(2022, time.July)},
+		"2023年第三季度": {date(2023, time.July), date(2023, time.October)},
+		"23年Q4":     {date(2023, time.October), date(2024, time.January)},
+	} {
+		r, err := dateParser.ParsePeriod(input)
+		assert(t, err, nil, input+" error")
+		assert(t, r.Start.Equal(expected.Start), true, input+" start mismatch")
+		assert(t, r.End.Equal(expected.End), true, input+" end mismatch")
+	}
+}
+
+func TestParseFiscalPeriod(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2024, time.February, 20, 12, 34, 56, 32, shanghai)
+	dateParser := NewDateTimeParser(base)
+	dateParser.Fiscal = FiscalCalendar{StartMonth: time.April, NamedByEndYear: true}
+	date := func(y int, m time.Month) time.Time {
+		return time.Date(y, m, 1, 0, 0, 0, 0, shanghai)
+	}
+	for input, expected := range map[string]Interval{
+		"本财年":      {date(2023, time.April), date(2024, time.April)},
+		"上财年":      {date(2022, time.April), date(2023, time.April)},
+		"本财季":      {date(2024, time.January), date(2024, time.April)},
+		"上财季":      {date(2023, time.October), date(2024, time.January)},
+		"FY24 Q2":  {date(2023, time.July), date(2023, time.October)},
+		"FY2025":   {date(2024, time.April), date(2025, time.April)},
+		"2024财年H2": {date(2023, time.October), date(2024, time.April)},
+		"本季度":      {date(2024, time.January), date(2024, time.April)},
+	} {
+		r, err := dateParser.ParsePeriod(input)
+		assert(t, err, nil, input+" error")
+		assert(t, r.Start.Equal(expected.Start), true, input+" start mismatch")
+		assert(t, r.End.Equal(expected.End), true, input+" end mismatch")
+	}
+	dateParser.Fiscal.NamedByEndYear = false
+	r, err := dateParser.ParsePeriod("FY24 Q2")
+	assert(t, err, nil, "error")
+	assert(t, r.Start.Equal(date(2024, time.July)), true, "start mismatch")
+	r, err = dateParser.ParsePeriod("本财年")
+	assert(t, err, nil, "error")
+	assert(t, r.Start.Equal(date(2023, time.April)), true, "start mismatch")
+}
@@ -0,0 +1,124 @@
+package datetimeparser
+
+import (
+	"errors"
+	"time"
+)
+
+// Granularity is how precisely an expression pinned down a time.
+type Granularity int
+
+const (
+	// GranularityDay means only a date was given.
+	GranularityDay Granularity = iota
+	// GranularityTime means a time of day was given.
+	GranularityTime
+)
+
+// Session resolves the expressions of one conversation in turn. Once a time
+// has been settled, later expressions fill in only the fields they mention,
+// so that 改成4点 keeps the date, and relative edits such as 推迟半小时 or
+// 再往后推一天 move the settled time rather than Base.
+type Session struct {
+	Parser *DateTimeParser
+
+	last        DateTimeParseResult
+	granularity Granularity
+	settled     bool
+}
+
+func NewSession(dp *DateTimeParser) *Session {
+	return &Session{
+		Parser: dp,
+	}
+}
+
+// Last returns the time settled so far and its granularity. ok is false
+// before anything has been parsed.
+func (s *Session) Last() (t time.Time, g Granularity, ok bool) {
+	if !s.settled {
+		return time.Time{}, GranularityDay, false
+	}
+	return s.Parser.resultTime(s.last), s.granularity, true
+}
+
+// Reset forgets the settled time.
+func (s *Session) Reset() {
+	s.last = DateTimeParseResult{}
+	s.granularity = GranularityDay
+	s.settled = false
+}
+
+// parseEdit parses 推迟/提前 style edits, returning the signed adjustment.
+func parseEdit(input string, d *Duration) (string, error) {
+	sign := 1
+	rest, err := parseRegex(input, "(再)?(往后推|向后推|推迟|延后|推后|延迟|晚)")
+	if err != nil {
+		sign = -1
+		rest, err = parseRegex(input, "(再)?(往前推|向前推|提前|提早|早)")
+	}
+	if err != nil {
+		return input, errors.New("edit not parsed")
+	}
+	rest, err = parseDuration(rest, d)
+	if err != nil {
+		return input, err
+	}
+	if sign < 0 {
+		*d = d.negate()
+	}
+	return rest, nil
+}
+
+func (s *Session) settle(result DateTimeParseResult, g Granularity) time.Time {
+	s.last = result
+	s.granularity = g
+	s.settled = true
+	return s.Parser.resultTime(result)
+}
+
+// ParseDateTime parses input in the context of the conversation so far and
+// makes the result the new settled time.
+func (s *Session) ParseDateTime(input string) (time.Time, error) {
+	dp := s.Parser
+	input = normalize(input).text
+	if s.settled {
+		var d Duration
+		rest, err := parseEdit(input, &d)
+		if err == nil && (!dp.Strict || rest == "") {
+			t := d.AddTo(dp.resultTime(s.last))
+			var r DateTimeParseResult
+			r.Location = t.Location()
+			dp.setInstant(&r, t)
+			return s.settle(r, s.granularity), nil
+		}
+	}
+	if rest, err := parseRegex(input, "(改成|改到|改为|换成|换到)"); err == nil {
+		input = rest
+	}
+
+	result, err := dp.parseInput(input)
+	if err != nil {
+		result, err = dp.parseDateInput(input)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if s.settled && s.granularity == GranularityTime {
+			result.Hour, result.Minute, result.Second = s.last.Hour, s.last.Minute, s.last.Second
+			return s.settle(result, GranularityTime), nil
+		}
+		return s.settle(result, GranularityDay), nil
+	}
+	rules := result.rules.names()
+	if !s.settled || hasRule(rules, "parseAnyDate", "parseTimePeriod", "parseMachineDateTime") {
+		return s.settle(result, GranularityTime), nil
+	}
+	// Only a time of day was given: keep the settled date, and its half of
+	// the day when no 上午/下午 or am/pm was said.
+	result.Year, result.Month, result.Day = s.last.Year, s.last.Month, s.last.Day
+	if s.granularity == GranularityTime && !hasRule(rules, "parseAmHourMinute", "parsePmHourMinute", "parseNoonHourMinute", "parseMeridiemTime") &&
+		s.last.Hour >= 12 && result.Hour >= 1 && result.Hour < 12 {
+		result.Hour += 12
+	}
+	return s.settle(result, GranularityTime), nil
+}
@@ -0,0 +1,38 @@
+package datetimeparser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSession(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 21, 12, 34, 56, 32, shanghai)
+	session := NewSession(NewDateTimeParser(base))
+	_, _, ok := session.Last()
+	assert(t, ok, false, "settled before parsing")
+	for _, step := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"明天下午3点开会", time.Date(2022, time.August, 22, 15, 0, 0, 0, shanghai)},
+		{"改成4点", time.Date(2022, time.August, 22, 16, 0, 0, 0, shanghai)},
+		{"再往后推一天", time.Date(2022, time.August, 23, 16, 0, 0, 0, shanghai)},
+		{"提前半小时", time.Date(2022, time.August, 23, 15, 30, 0, 0, shanghai)},
+		{"改到8月30号", time.Date(2022, time.August, 30, 15, 30, 0, 0, shanghai)},
+		{"上午10点", time.Date(2022, time.August, 30, 10, 0, 0, 0, shanghai)},
+		{"推迟两小时", time.Date(2022, time.August, 30, 12, 0, 0, 0, shanghai)},
+	} {
+		r, err := session.ParseDateTime(step.input)
+		assert(t, err, nil, step.input+" error")
+		assert(t, r.Equal(step.expected), true, step.input+" time mismatch")
+	}
+	last, g, ok := session.Last()
+	assert(t, ok, true, "not settled")
+	assert(t, g, GranularityTime, "granularity mismatch")
+	assert(t, last.Hour(), 12, "hour mismatch")
+	session.Reset()
+	r, err := session.ParseDateTime("4点")
+	assert(t, err, nil, "error")
+	assert(t, r.Equal(time.Date(2022, time.August, 21, 4, 0, 0, 0, shanghai)), true, "time mismatch after reset")
+}
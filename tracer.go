@@ -0,0 +1,76 @@
+package datetimeparser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TraceNode is one attempt to apply a grammar rule. Consumed and Result are
+// only meaningful when Err is nil. Input and Consumed are the normalized text
+// the grammars see, with full-width forms folded and filler dropped.
+type TraceNode struct {
+	Rule     string
+	Input    string
+	Consumed string
+	Result   DateTimeParseResult
+	Err      error
+	Children []*TraceNode
+}
+
+// Tracer collects the tree of rules attempted by the DateTimeParser it is
+// attached to. It is not safe for concurrent use.
+type Tracer struct {
+	Roots []*TraceNode
+	stack []*TraceNode
+}
+
+func (t *Tracer) enter(rule string, input string) {
+	n := &TraceNode{Rule: rule, Input: input}
+	if len(t.stack) == 0 {
+		t.Roots = append(t.Roots, n)
+	} else {
+		parent := t.stack[len(t.stack)-1]
+		parent.Children = append(parent.Children, n)
+	}
+	t.stack = append(t.stack, n)
+}
+
+func (t *Tracer) exit(consumed string, result DateTimeParseResult, err error) {
+	n := t.stack[len(t.stack)-1]
+	t.stack = t.stack[:len(t.stack)-1]
+	n.Err = err
+	if err == nil {
+		n.Consumed = consumed
+		n.Result = result
+	}
+}
+
+// Reset discards everything recorded so far.
+func (t *Tracer) Reset() {
+	t.Roots = nil
+	t.stack = nil
+}
+
+// Explain renders the recorded attempts as an indented tree, one rule per
+// line.
+func (t *Tracer) Explain() string {
+	var b strings.Builder
+	for _, n := range t.Roots {
+		explainNode(&b, n, 0)
+	}
+	return b.String()
+}
+
+func explainNode(b *strings.Builder, n *TraceNode, depth int) {
+	indent := strings.Repeat("  ", depth)
+	if n.Err != nil {
+		fmt.Fprintf(b, "%s%s %q: %v\n", indent, n.Rule, n.Input, n.Err)
+	} else {
+		r := n.Result
+		fmt.Fprintf(b, "%s%s %q: matched %q -> %04d-%02d-%02d %02d:%02d:%02d\n", indent, n.Rule, n.Input, n.Consumed,
+			r.Year, r.Month, r.Day, r.Hour, r.Minute, r.Second)
+	}
+	for _, c := range n.Children {
+		explainNode(b, c, depth+1)
+	}
+}
@@ -0,0 +1,29 @@
+package datetimeparser
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTracer(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 21, 12, 34, 56, 32, shanghai)
+	dateParser := NewDateTimeParser(base)
+	dateParser.Tracer = &Tracer{}
+	_, err := dateParser.ParseDateTime("明天上午8点")
+	assert(t, err, nil, "error")
+	roots := dateParser.Tracer.Roots
+	for _, n := range roots[:len(roots)-1] {
+		assert(t, n.Err != nil, true, n.Rule+" error mismatch")
+	}
+	last := roots[len(roots)-1]
+	assert(t, last.Rule, "parseLocalDateTime", "last root mismatch")
+	assert(t, last.Consumed, "明天上午8点", "consumed mismatch")
+	assert(t, last.Result.Day, 22, "day mismatch")
+	assert(t, last.Result.Hour, 8, "hour mismatch")
+	explain := dateParser.Tracer.Explain()
+	assert(t, strings.Contains(explain, `  parseAnyDate "明天上午8点": matched "明天" -> 2022-08-22 00:00:00`), true, "explain mismatch")
+	dateParser.Tracer.Reset()
+	assert(t, len(dateParser.Tracer.Roots), 0, "reset mismatch")
+}
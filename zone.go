@@ -0,0 +1,197 @@
+package datetimeparser
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type zoneName struct {
+	name     string
+	location string
+}
+
+// zoneNames maps city and region names, written with a 时间 suffix as in
+// 纽约时间, to IANA locations. Abbreviations are in zoneAbbreviations.
+var zoneNames = []zoneName{
+	{"北京", "Asia/Shanghai"},
+	{"上海", "Asia/Shanghai"},
+	{"中国", "Asia/Shanghai"},
+	{"香港", "Asia/Hong_Kong"},
+	{"台北", "Asia/Taipei"},
+	{"东京", "Asia/Tokyo"},
+	{"日本", "Asia/Tokyo"},
+	{"首尔", "Asia/Seoul"},
+	{"韩国", "Asia/Seoul"},
+	{"新加坡", "Asia/Singapore"},
+	{"迪拜", "Asia/Dubai"},
+	{"莫斯科", "Europe/Moscow"},
+	{"伦敦", "Europe/London"},
+	{"英国", "Europe/London"},
+	{"巴黎", "Europe/Paris"},
+	{"柏林", "Europe/Berlin"},
+	{"纽约", "America/New_York"},
+	{"美东", "America/New_York"},
+	{"芝加哥", "America/Chicago"},
+	{"洛杉矶", "America/Los_Angeles"},
+	{"旧金山", "America/Los_Angeles"},
+	{"美西", "America/Los_Angeles"},
+	{"悉尼", "Australia/Sydney"},
+}
+
+type zoneAbbreviation struct {
+	name   string
+	offset time.Duration
+}
+
+// zoneAbbreviations maps abbreviations to the fixed offsets they stand for,
+// so that PST is UTC-8 even in summer.
+var zoneAbbreviations = []zoneAbbreviation{
+	{"EST", -5 * time.Hour},
+	{"EDT", -4 * time.Hour},
+	{"CDT", -5 * time.Hour},
+	{"PST", -8 * time.Hour},
+	{"PDT", -7 * time.Hour},
+	{"BST", 1 * time.Hour},
+	{"CET", 1 * time.Hour},
+	{"CEST", 2 * time.Hour},
+	{"JST", 9 * time.Hour},
+	{"KST", 9 * time.Hour},
+	{"HKT", 8 * time.Hour},
+	{"SGT", 8 * time.Hour},
+}
+
+var ianaZoneRegex = regexp.MustCompile(`^[A-Z][A-Za-z_]+(/[A-Za-z_+\-0-9]+)+`)
+
+var offsetZoneRegex = regexp.MustCompile(`^(UTC|GMT)(([+-])(\d{1,2})(:?(\d{2}))?)?`)
+
+func parseZoneName(input string, r **time.Location) (string, error) {
+	for _, z := range zoneNames {
+		if !strings.HasPrefix(input, z.name+"时间") {
+			continue
+		}
+		rest := input[len(z.name+"时间"):]
+		loc, err := time.LoadLocation(z.location)
+		if err != nil {
+			return input, err
+		}
+		*r = loc
+		return rest, nil
+	}
+	return input, errors.New("zone name not parsed")
+}
+
+func parseZoneAbbreviation(input string, r **time.Location) (string, error) {
+	for _, z := range zoneAbbreviations {
+		if !strings.HasPrefix(input, z.name) {
+			continue
+		}
+		rest := input[len(z.name):]
+		if next, _ := utf8.DecodeRuneInString(rest); isASCIIAlnum(next) || next == '_' {
+			continue
+		}
+		*r = time.FixedZone(z.name, int(z.offset/time.Second))
+		return rest, nil
+	}
+	return input, errors.New("zone abbreviation not parsed")
+}
+
+func parseZoneOffset(input string, r **time.Location) (string, error) {
+	m := offsetZoneRegex.FindStringSubmatch(input)
+	if m == nil {
+		return input, errors.New("zone offset not parsed")
+	}
+	if m[2] == "" {
+		*r = time.UTC
+		return input[len(m[0]):], nil
+	}
+	h, _ := strconv.Atoi(m[4])
+	min, _ := strconv.Atoi(m[6])
+	if h > 14 || min > 59 {
+		return input, errors.New("zone offset out of range")
+	}
+	offset := h*3600 + min*60
+	if m[3] == "-" {
+		offset = -offset
+	}
+	*r = time.FixedZone(m[0], offset)
+	return input[len(m[0]):], nil
+}
+
+func parseZoneIANA(input string, r **time.Location) (string, error) {
+	m := ianaZoneRegex.FindString(input)
+	if m == "" {
+		return input, errors.New("zone not parsed")
+	}
+	loc, err := time.LoadLocation(m)
+	if err != nil {
+		return input, err
+	}
+	*r = loc
+	return input[len(m):], nil
+}
+
+func parseZone(input string, r **time.Location) (string, error) {
+	rest, err := parseAnyOf(ParseFuncList[*time.Location]{
+		parseZoneName,
+		parseZoneIANA,
+		parseZoneOffset,
+		parseZoneAbbreviation,
+	})(input, r)
+	if err != nil {
+		return input, err
+	}
+	return strings.TrimLeft(rest, " "), nil
+}
+
+// inLocation returns a copy of dp whose relative expressions resolve against
+// Base as seen in loc.
+func (dp *DateTimeParser) inLocation(loc *time.Location) *DateTimeParser {
+	zdp := *dp
+	zdp.Base = dp.Base.In(loc)
+	return &zdp
+}
+
+func (dp *DateTimeParser) parseZonePrefix(input string, result *DateTimeParseResult) (string, error) {
+	var loc *time.Location
+	rest, err := parseZone(input, &loc)
+	if err != nil {
+		return input, err
+	}
+	zdp := dp.inLocation(loc)
+	r := zdp.baseResult()
+	rest, err = zdp.parseLocalDateTime(rest, &r)
+	if err != nil {
+		return input, err
+	}
+	r.Location = loc
+	*result = r
+	return rest, nil
+}
+
+// withZoneSuffix runs f and, when a zone such as PST follows the text it
+// consumed, resolves that text again in the zone, as in 明天下午3点PST.
+func (dp *DateTimeParser) withZoneSuffix(f ParseFunc[DateTimeParseResult]) ParseFunc[DateTimeParseResult] {
+	return func(input string, result *DateTimeParseResult) (string, error) {
+		rest, err := f(input, result)
+		if err != nil {
+			return input, err
+		}
+		var loc *time.Location
+		zoneRest, err := parseZone(strings.TrimLeft(rest, " "), &loc)
+		if err != nil {
+			return rest, nil
+		}
+		zdp := dp.inLocation(loc)
+		r := zdp.baseResult()
+		if _, err = zdp.parseLocalDateTime(input[:len(input)-len(rest)], &r); err != nil {
+			return rest, nil
+		}
+		r.Location = loc
+		*result = r
+		return zoneRest, nil
+	}
+}
@@ -0,0 +1,65 @@
+package datetimeparser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseZoneNamePrefix(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	newYork, _ := time.LoadLocation("America/New_York")
+	base := time.Date(2022, time.August, 21, 8, 0, 0, 0, shanghai)
+	dateParser := NewDateTimeParser(base)
+	r, err := dateParser.ParseDateTime("纽约时间明天上午9点")
+	assert(t, err, nil, "error")
+	assert(t, r.Location().String(), "America/New_York", "location mismatch")
+	// 8:00 on the 21st in Shanghai is still the 20th in New York.
+	assert(t, r.Equal(time.Date(2022, time.August, 21, 9, 0, 0, 0, newYork)), true, "time mismatch")
+	dateParser.ConvertToBaseLocation = true
+	r, err = dateParser.ParseDateTime("纽约时间明天上午9点")
+	assert(t, err, nil, "error")
+	assert(t, r.Location(), shanghai, "location mismatch")
+	assert(t, r.Day(), 21, "day mismatch")
+	assert(t, r.Hour(), 21, "hour mismatch")
+}
+
+func TestParseZoneOffset(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.August, 21, 12, 0, 0, 0, shanghai)
+	dateParser := NewDateTimeParser(base)
+	r, err := dateParser.ParseDateTime("UTC 15:00")
+	assert(t, err, nil, "error")
+	assert(t, r.Equal(time.Date(2022, time.August, 21, 15, 0, 0, 0, time.UTC)), true, "time mismatch")
+	r, err = dateParser.ParseDateTime("15:00 GMT-5")
+	assert(t, err, nil, "error")
+	_, offset := r.Zone()
+	assert(t, offset, -5*3600, "offset mismatch")
+	assert(t, r.Hour(), 15, "hour mismatch")
+	r, err = dateParser.ParseDateTime("Asia/Tokyo 明天下午3点")
+	assert(t, err, nil, "error")
+	assert(t, r.Location().String(), "Asia/Tokyo", "location mismatch")
+	assert(t, r.Day(), 22, "day mismatch")
+	r, err = dateParser.ParseDateTime("明天下午3点PST")
+	assert(t, err, nil, "error")
+	assert(t, r.Location().String(), "PST", "location mismatch")
+	assert(t, r.Hour(), 15, "hour mismatch")
+}
+
+func TestParseZoneAbbreviationInSummer(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	base := time.Date(2022, time.July, 15, 12, 0, 0, 0, shanghai)
+	dateParser := NewDateTimeParser(base)
+	for input, expected := range map[string]int{
+		"15:00 PST":  -8 * 3600,
+		"15:00 PDT":  -7 * 3600,
+		"15:00 EST":  -5 * 3600,
+		"15:00 CET":  1 * 3600,
+		"15:00 CEST": 2 * 3600,
+	} {
+		r, err := dateParser.ParseDateTime(input)
+		assert(t, err, nil, input+" error")
+		_, offset := r.Zone()
+		assert(t, offset, expected, input+" offset mismatch")
+		assert(t, r.Hour(), 15, input+" hour mismatch")
+	}
+}